@@ -0,0 +1,133 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"seedfast/cli/internal/auth"
+	"seedfast/cli/internal/backend"
+	"seedfast/cli/internal/manifest"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var accountJSON bool
+
+// accountReport is the --json output of 'seedfast account'.
+type accountReport struct {
+	Account string `json:"account"`
+	Plan    string `json:"plan,omitempty"`
+	// Usage is null when the backend does not report usage
+	Usage *backend.Usage `json:"usage"`
+}
+
+// accountCmd shows the plan and credit usage of the logged-in account.
+var accountCmd = &cobra.Command{
+	Use:   "account",
+	Short: "Show plan, remaining credits and usage for the current period",
+	Long: `The account command shows the plan of the logged-in account, the credits remaining
+in the current billing period and how many sessions and rows were seeded in it.
+Failed sessions are not charged.
+
+Use 'seedfast sessions' to list recent seeding sessions.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		if accountJSON {
+			// Keep stdout for the JSON report
+			pterm.SetDefaultOutput(os.Stderr)
+		}
+		if st, err := auth.Load(); err != nil || !st.LoggedIn {
+			return withExitCode(exitNotLoggedIn, errors.New("not logged in; run 'seedfast login'"))
+		}
+		m, err := manifest.GetEndpoints(ctx)
+		if err != nil {
+			return err
+		}
+		svc := auth.NewService(m.HTTPBaseURL(), m.HTTP)
+
+		// Usage first: it refreshes an expired access token
+		var report accountReport
+		usage, err := svc.GetUsage(ctx)
+		switch {
+		case err == nil:
+			report.Usage = usage
+		case errors.Is(err, backend.ErrNotSupported):
+		case errors.Is(err, backend.ErrUnauthorized):
+			return withExitCode(exitNotLoggedIn, errors.New("session expired; run 'seedfast login'"))
+		default:
+			return fmt.Errorf("load usage: %w", err)
+		}
+		user, err := svc.GetUserData(ctx)
+		if err != nil {
+			if errors.Is(err, backend.ErrUnauthorized) {
+				return withExitCode(exitNotLoggedIn, errors.New("session expired; run 'seedfast login'"))
+			}
+			return fmt.Errorf("load account: %w", err)
+		}
+		report.Account = accountName(user)
+		report.Plan = user.Plan
+		if usage != nil && usage.Plan != "" {
+			report.Plan = usage.Plan
+		}
+
+		if accountJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(report)
+		}
+		printAccountReport(report)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(accountCmd)
+	accountCmd.Flags().BoolVar(&accountJSON, "json", false, "Print the account as JSON")
+}
+
+// accountName returns the identifier to show for the user.
+func accountName(u *backend.User) string {
+	if id := u.Identifier(); id != "" {
+		return id
+	}
+	return "user"
+}
+
+// printAccountReport renders the account summary.
+func printAccountReport(r accountReport) {
+	label := pterm.NewStyle(pterm.FgLightCyan)
+	plan := r.Plan
+	if plan == "" {
+		plan = "unknown"
+	}
+	pterm.Println()
+	pterm.Println(label.Sprint("→ Account:    ") + pterm.NewStyle(pterm.FgCyan, pterm.Bold).Sprint(r.Account))
+	pterm.Println(label.Sprint("→ Plan:       ") + plan)
+	u := r.Usage
+	if u == nil {
+		pterm.Println()
+		pterm.Println("Credit usage is not available from this backend.")
+		return
+	}
+	credits := fmt.Sprintf("%d remaining, %d used", u.CreditsRemaining, u.CreditsUsed)
+	if u.CreditsIncluded > 0 {
+		credits = fmt.Sprintf("%d remaining, %d of %d used", u.CreditsRemaining, u.CreditsUsed, u.CreditsIncluded)
+	}
+	creditStyle := pterm.NewStyle(pterm.FgGreen)
+	if u.CreditsRemaining <= 0 {
+		creditStyle = pterm.NewStyle(pterm.FgRed)
+	}
+	pterm.Println(label.Sprint("→ Credits:    ") + creditStyle.Sprint(credits))
+	if !u.PeriodStart.IsZero() && !u.PeriodEnd.IsZero() {
+		pterm.Println(label.Sprint("→ Period:     ") + u.PeriodStart.Local().Format("2006-01-02") + " – " + u.PeriodEnd.Local().Format("2006-01-02"))
+	}
+	pterm.Println(label.Sprint("→ Usage:      ") + fmt.Sprintf("%d sessions, %d rows this period", u.Sessions, u.Rows))
+	pterm.Println()
+}
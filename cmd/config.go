@@ -0,0 +1,180 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"seedfast/cli/internal/config"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configOnce     sync.Once
+	configResolved *config.Resolved
+	configErr      error
+
+	configShowOutput string
+)
+
+// loadConfig resolves seedfast.yaml and environment settings once per process.
+// Commands apply their own flags on top of the result.
+func loadConfig() (*config.Resolved, error) {
+	configOnce.Do(func() {
+		configResolved, configErr = config.Load()
+	})
+	return configResolved, configErr
+}
+
+// configCmd groups the commands inspecting seedfast.yaml configuration.
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect project and user configuration",
+	Long: `Seedfast reads optional settings from seedfast.yaml. The nearest file found in the
+current directory or any parent is the project file; a seedfast.yaml in the Seedfast
+user directory (~/.config/seedfast by default) applies to every project.
+
+Settings are resolved with the precedence:
+  flags > environment variables > project file > user file > defaults
+
+Example seedfast.yaml:
+
+  connection: staging
+  schemas:
+    include: [public, billing]
+    exclude: [audit]
+  tables:
+    include: ["billing.*", users]
+    exclude: ["*_archive"]
+  scale: medium
+  rows:
+    users: 500
+  locale: de-DE
+  ask_human:
+    default_answer: "yes"
+  workers: 8
+  safety:
+    production_pattern: "(?i)prod"
+    max_database_bytes: 10737418240
+    max_table_rows: 1000000
+  output: text`,
+}
+
+// configShowCmd prints the effective configuration and where each value came from.
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the effective configuration",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			pterm.Println("❌ " + err.Error())
+			return err
+		}
+		format := cfg.OutputFormat()
+		if configShowOutput != "" {
+			format = configShowOutput
+		}
+		if format == config.OutputJSON {
+			out := struct {
+				Config  config.Config     `json:"config"`
+				Files   []string          `json:"files"`
+				Sources map[string]string `json:"sources"`
+			}{cfg.Config, cfg.Files, cfg.Sources}
+			if out.Files == nil {
+				out.Files = []string{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(out)
+		}
+
+		if len(cfg.Files) == 0 {
+			pterm.Println("No seedfast.yaml found; using defaults and environment variables.")
+		} else {
+			pterm.Println("Configuration files (lowest precedence first):")
+			for _, f := range cfg.Files {
+				pterm.Println("  • " + f)
+			}
+		}
+		pterm.Println()
+		data := pterm.TableData{{"Setting", "Value", "Source"}}
+		for _, key := range config.Keys {
+			value, source := cfg.Value(key), cfg.Sources[key]
+			if value == "" {
+				value, source = configDefault(key), "default"
+			}
+			data = append(data, []string{key, value, source})
+		}
+		return pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	},
+}
+
+// configValidateCmd checks configuration files without running anything.
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [file...]",
+	Short: "Validate seedfast.yaml files",
+	Long: `Validate checks the given files, or the project and user files that apply to the
+current directory, and reports every unknown key and invalid value.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		files := args
+		if len(files) == 0 {
+			wd, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			files = config.Discover(wd)
+			if len(files) == 0 {
+				pterm.Println("No seedfast.yaml found in the current directory, its parents or the user directory.")
+				return nil
+			}
+		}
+		failed := 0
+		for _, f := range files {
+			if _, err := config.ReadFile(f); err != nil {
+				failed++
+				pterm.Println("❌ " + err.Error())
+				continue
+			}
+			pterm.Println("✓ " + f)
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d invalid configuration file(s)", failed)
+		}
+		return nil
+	},
+}
+
+// configDefault describes the built-in value of an unset key.
+func configDefault(key string) string {
+	switch key {
+	case config.KeyWorkers:
+		return fmt.Sprint(config.DefaultWorkers)
+	case config.KeyOutput:
+		return config.OutputText
+	case config.KeyConnection:
+		return "(saved default connection)"
+	case config.KeySchemasInclude, config.KeyTablesInclude:
+		return "(all)"
+	case config.KeyScale, config.KeyLocale:
+		return "(backend default)"
+	case config.KeyAskHumanAnswer:
+		return "(ask)"
+	case config.KeyProductionPattern, config.KeyMaxDatabaseBytes, config.KeyMaxTableRows:
+		return "(built-in)"
+	}
+	return ""
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configShowCmd.Flags().StringVarP(&configShowOutput, "output", "o", "", "Output format: text or json")
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"seedfast/cli/internal/diagnose"
+	"seedfast/cli/internal/dsn"
+	"seedfast/cli/internal/logging"
+	"seedfast/cli/internal/sqlexec"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// runConnectionTest connects to normalizedDSN and prints a diagnostic report
+// without saving anything. Failures are explained with suggested fixes.
+func runConnectionTest(ctx context.Context, normalizedDSN string) error {
+	dbType := dsn.DetectDBType(normalizedDSN)
+	info, _ := dsn.ParseInfo(normalizedDSN)
+
+	pterm.Println()
+	pterm.Println(pterm.NewStyle(pterm.FgLightCyan).Sprint("→ Database:   ") + pterm.NewStyle(pterm.FgCyan, pterm.Bold).Sprint(deriveDBName(normalizedDSN)))
+	pterm.Println(pterm.NewStyle(pterm.FgLightCyan).Sprint("→ Connection: ") + pterm.NewStyle(pterm.FgLightBlue).Sprint(logging.Mask(normalizedDSN)))
+	pterm.Println()
+
+	ctxTest, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if dbType != dsn.DBTypePostgreSQL {
+		exec, err := sqlexec.Open(ctxTest, normalizedDSN)
+		if err != nil {
+			pterm.Println("❌ Connection failed")
+			pterm.Println("   " + logging.PresentError("connect", err))
+			return withExitCode(exitConnection, err)
+		}
+		exec.Close()
+		pterm.Println("✅ Connection succeeded")
+		pterm.Printf("   Detailed diagnostics are available for PostgreSQL only (target is %s)\n", dbType)
+		return nil
+	}
+
+	pool, err := pgxpool.New(ctxTest, normalizedDSN)
+	if err != nil {
+		pterm.Println("❌ Invalid connection string")
+		pterm.Println("   " + logging.PresentError("connect", err))
+		return withExitCode(exitUsage, err)
+	}
+	defer pool.Close()
+
+	var allowSchema func(string) bool
+	if cfg, err := loadConfig(); err == nil {
+		allowSchema = cfg.Schemas.AllowsSchema
+	}
+	report, err := diagnose.Inspect(ctxTest, pool, allowSchema)
+	if err != nil {
+		pterm.Println("❌ Connection failed")
+		pterm.Println("   " + logging.PresentError("connect", err))
+		if fixes := diagnose.Fixes(err, info); len(fixes) > 0 {
+			pterm.Println()
+			pterm.Println("How to fix:")
+			for _, f := range fixes {
+				pterm.Println("   → " + f)
+			}
+		}
+		return withExitCode(exitConnection, err)
+	}
+
+	printConnectionReport(report)
+	return nil
+}
+
+// printConnectionReport renders a diagnose.Report.
+func printConnectionReport(r *diagnose.Report) {
+	label := pterm.NewStyle(pterm.FgLightCyan)
+	pterm.Printf("✅ Connection succeeded (%s)\n\n", r.Latency.Round(time.Millisecond))
+
+	pterm.Println(label.Sprint("→ Server:     ") + "PostgreSQL " + r.ServerVersion)
+	ssl := "off"
+	if r.SSL {
+		ssl = fmt.Sprintf("on (%s, %s)", r.SSLVersion, r.SSLCipher)
+	}
+	pterm.Println(label.Sprint("→ SSL:        ") + ssl)
+	role := r.Role
+	if r.Superuser {
+		role += " (superuser)"
+	}
+	pterm.Println(label.Sprint("→ Role:       ") + role)
+	extensions := make([]string, len(r.Extensions))
+	for i, e := range r.Extensions {
+		extensions[i] = e.Name + " " + e.Version
+	}
+	if len(extensions) == 0 {
+		extensions = []string{"none"}
+	}
+	pterm.Println(label.Sprint("→ Extensions: ") + strings.Join(extensions, ", "))
+	pterm.Println()
+
+	if len(r.Schemas) > 0 {
+		yesNo := func(b bool) string {
+			if b {
+				return "yes"
+			}
+			return "no"
+		}
+		data := pterm.TableData{{"Schema", "Tables", "USAGE", "CREATE", "SELECT", "INSERT"}}
+		for _, s := range r.Schemas {
+			data = append(data, []string{
+				s.Name,
+				fmt.Sprint(s.Tables),
+				yesNo(s.Usage),
+				yesNo(s.Create),
+				fmt.Sprintf("%d/%d", s.Select, s.Tables),
+				fmt.Sprintf("%d/%d", s.Insert, s.Tables),
+			})
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+		pterm.Println()
+	}
+
+	for _, w := range r.Warnings() {
+		pterm.Println("⚠️  " + w)
+	}
+}
+
+// testConnection implements 'seedfast connect --test'. It does not require a
+// login: nothing is sent to the Seedfast service.
+func testConnection(cmd *cobra.Command) error {
+	rawDSN, err := readConnectDSN("Enter DSN to test (leave empty for the saved connection): ")
+	if err != nil {
+		pterm.Println("❌ " + err.Error())
+		return withExitCode(exitUsage, err)
+	}
+	if rawDSN == "" {
+		rawDSN, err = resolveConnectionDSN(connectName)
+		if err != nil || rawDSN == "" {
+			pterm.Println("⚠️  No database connection configured")
+			pterm.Println("   Please run: seedfast connect")
+			return withExitCode(exitUsage, errors.New("no connection to test"))
+		}
+	}
+
+	normalizedDSN, err := dsn.Parse(rawDSN)
+	if err != nil {
+		pterm.Println("❌ " + err.Error())
+		return withExitCode(exitUsage, err)
+	}
+	return runConnectionTest(cmd.Context(), normalizedDSN)
+}
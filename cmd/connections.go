@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"seedfast/cli/internal/dsn"
+	"seedfast/cli/internal/keychain"
+	"seedfast/cli/internal/logging"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// connectionsCmd groups the commands managing named connection profiles.
+// Profiles are created with 'seedfast connect --name <name>'.
+var connectionsCmd = &cobra.Command{
+	Use:     "connections",
+	Aliases: []string{"connection", "conn"},
+	Short:   "Manage saved database connection profiles",
+	Long: `The connections command lists, selects and removes the database connections saved
+with 'seedfast connect --name <name>'. Each DSN is stored separately in the OS keychain.
+The default connection is used by 'seedfast seed' unless --connection is given.`,
+}
+
+// connectionsListCmd prints all saved profiles with their masked DSNs.
+var connectionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved connections",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		km, err := keychain.GetManager()
+		if err != nil {
+			pterm.Println("❌ Secure storage is not available on this system.")
+			return err
+		}
+		names, def, err := km.ListProfiles()
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			pterm.Println("No saved connections. Add one with: seedfast connect --name <name>")
+			return nil
+		}
+		data := pterm.TableData{{"", "Name", "Database", "Connection"}}
+		for _, name := range names {
+			marker := ""
+			if name == def {
+				marker = "*"
+			}
+			database, masked := "", ""
+			if raw, err := km.LoadProfileDSN(name); err == nil {
+				if normalized, err := dsn.Parse(raw); err == nil {
+					database = deriveDBName(normalized)
+					masked = logging.Mask(normalized)
+				} else {
+					masked = logging.Mask(raw)
+				}
+			}
+			data = append(data, []string{marker, name, database, masked})
+		}
+		return pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	},
+}
+
+// connectionsUseCmd selects the default profile.
+var connectionsUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Make a saved connection the default",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		km, err := keychain.GetManager()
+		if err != nil {
+			pterm.Println("❌ Secure storage is not available on this system.")
+			return err
+		}
+		if err := km.SetDefaultProfile(args[0]); err != nil {
+			pterm.Println("❌ " + err.Error())
+			return err
+		}
+		pterm.Printf("✅ '%s' is now the default connection\n", args[0])
+		return nil
+	},
+}
+
+// connectionsRemoveCmd deletes a profile from the keychain.
+var connectionsRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a saved connection",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		km, err := keychain.GetManager()
+		if err != nil {
+			pterm.Println("❌ Secure storage is not available on this system.")
+			return err
+		}
+		_, def, _ := km.ListProfiles()
+		if err := km.RemoveProfile(args[0]); err != nil {
+			pterm.Println("❌ " + err.Error())
+			return err
+		}
+		pterm.Printf("✅ Removed connection '%s'\n", args[0])
+		if def == args[0] {
+			pterm.Println("   It was the default; pick another with 'seedfast connections use <name>'")
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(connectionsCmd)
+	connectionsCmd.AddCommand(connectionsListCmd, connectionsUseCmd, connectionsRemoveCmd)
+}
@@ -48,7 +48,7 @@ The password in the DSN will be replaced with *** for security.`,
 			km, err := keychain.GetManager()
 			if err != nil {
 				pterm.Println("❌ Secure storage is not available on this system")
-				pterm.Println("   Keychain is only supported on macOS and Windows")
+				pterm.Println("   Set SEEDFAST_DSN or DATABASE_URL to provide the connection string")
 				return err
 			}
 
@@ -0,0 +1,11 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+// Database dialects beyond PostgreSQL register their executors with sqlexec
+// when imported.
+import (
+	_ "seedfast/cli/internal/sqlexec/mysql"
+	_ "seedfast/cli/internal/sqlexec/sqlite"
+)
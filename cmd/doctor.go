@@ -0,0 +1,296 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"seedfast/cli/internal/auth"
+	"seedfast/cli/internal/backend"
+	"seedfast/cli/internal/diagnose"
+	"seedfast/cli/internal/doctor"
+	"seedfast/cli/internal/dsn"
+	"seedfast/cli/internal/httpclient"
+	"seedfast/cli/internal/keychain"
+	"seedfast/cli/internal/logging"
+	"seedfast/cli/internal/manifest"
+	"seedfast/cli/internal/sqlexec"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var doctorOutput string
+
+// doctorCmd checks every component seeding depends on and reports the results.
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the local setup, backend and database connection",
+	Long: `The doctor command runs the checks otherwise done with whoami, dbinfo and connect
+in one go: secure storage, stored credentials and token expiry, the endpoint manifest
+and its signature, the gRPC endpoint and its TLS handshake, the database connection
+and privileges, and whether a newer CLI version is available.
+
+Checks that need the network report their failure and the rest still run, so doctor
+is useful offline. Use --output json to attach the report to a support ticket; secrets
+in the report are masked. The command exits with 1 when any check fails.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if doctorOutput != "" && doctorOutput != "text" && doctorOutput != "json" {
+			return withExitCode(exitUsage, fmt.Errorf("unknown output format %q (use text or json)", doctorOutput))
+		}
+		if doctorOutput == "json" {
+			// Keep stdout for the JSON report
+			pterm.SetDefaultOutput(os.Stderr)
+		}
+		report := doctor.Run(cmd.Context(), Version, 15*time.Second, doctorChecks())
+
+		if doctorOutput == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(report); err != nil {
+				return err
+			}
+		} else {
+			printDoctorReport(report)
+		}
+		if n := report.Failed(); n > 0 {
+			return fmt.Errorf("%d of %d checks failed", n, len(report.Results))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().StringVarP(&doctorOutput, "output", "o", "", "Output format: text or json")
+}
+
+// doctorChecks returns the checks in the order they run. Later checks share
+// the manifest fetched by the manifest check.
+func doctorChecks() []doctor.Check {
+	var m *manifest.Manifest
+	fail := func(err error) (doctor.Status, string) {
+		return doctor.StatusFail, maskDetail(err)
+	}
+
+	return []doctor.Check{
+		{
+			Name: "keychain",
+			Run: func(ctx context.Context) (doctor.Status, string) {
+				if _, err := keychain.GetManager(); err != nil {
+					if source, _ := auth.CredentialSource(); source != "" && source != auth.SourceKeychain {
+						return doctor.StatusWarn, fmt.Sprintf("unavailable, not needed with %s credentials: %s", source, maskDetail(err))
+					}
+					return fail(err)
+				}
+				return doctor.StatusPass, "secure storage available"
+			},
+		},
+		{
+			Name: "credentials",
+			Run: func(ctx context.Context) (doctor.Status, string) {
+				st, err := auth.Load()
+				if err != nil {
+					return fail(err)
+				}
+				if !st.LoggedIn {
+					return doctor.StatusFail, "not logged in; run 'seedfast login'"
+				}
+				source, _ := auth.CredentialSource()
+				return doctor.StatusPass, fmt.Sprintf("logged in as %s (%s)", st.Account, source)
+			},
+		},
+		{
+			Name: "manifest",
+			Run: func(ctx context.Context) (doctor.Status, string) {
+				var err error
+				if m, err = manifest.GetEndpoints(ctx); err != nil {
+					return fail(err)
+				}
+				detail := fmt.Sprintf("%s, agent %s", manifest.Source(), m.GRPCAddress())
+				if manifest.AllowUnsigned() {
+					return doctor.StatusWarn, detail + "; unsigned manifests are allowed (" + manifest.EnvAllowUnsigned + ")"
+				}
+				return doctor.StatusPass, detail + ", signature verified"
+			},
+		},
+		{
+			Name:     "token",
+			Requires: []string{"credentials"},
+			Run: func(ctx context.Context) (doctor.Status, string) {
+				return checkToken(ctx, m)
+			},
+		},
+		{
+			Name:     "grpc",
+			Requires: []string{"manifest"},
+			Run: func(ctx context.Context) (doctor.Status, string) {
+				addr := m.GRPCAddress()
+				roots, err := httpclient.RootCAs()
+				if err != nil {
+					return fail(err)
+				}
+				info, err := doctor.ProbeTLS(ctx, addr, &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: roots})
+				if err != nil {
+					return doctor.StatusFail, fmt.Sprintf("%s: %s", addr, maskDetail(err))
+				}
+				detail := fmt.Sprintf("%s reachable, %s handshake in %s, certificate valid until %s",
+					addr, info.Version, info.Handshake.Round(time.Millisecond), info.CertExpiry.Format("2006-01-02"))
+				if time.Until(info.CertExpiry) < 14*24*time.Hour {
+					return doctor.StatusWarn, detail
+				}
+				return doctor.StatusPass, detail
+			},
+		},
+		{
+			Name: "database",
+			Run:  checkDatabase,
+		},
+		{
+			Name:     "cli version",
+			Requires: []string{"manifest"},
+			Run: func(ctx context.Context) (doctor.Status, string) {
+				latest, err := backend.New(m.HTTPBaseURL(), m.HTTP).GetCLIVersion(ctx)
+				if err != nil || latest == "" {
+					return doctor.StatusWarn, fmt.Sprintf("%s installed; latest version unknown", Version)
+				}
+				if latest != Version {
+					return doctor.StatusWarn, fmt.Sprintf("%s installed, %s available: brew upgrade argon-it/tap/seedfast", Version, latest)
+				}
+				return doctor.StatusPass, Version + " (latest)"
+			},
+		},
+	}
+}
+
+// checkToken inspects the access token offline and, when the manifest is
+// available, asks the backend whether it is accepted. It never refreshes or
+// clears stored credentials.
+func checkToken(ctx context.Context, m *manifest.Manifest) (doctor.Status, string) {
+	st, err := auth.Tokens()
+	if err != nil {
+		return doctor.StatusFail, maskDetail(err)
+	}
+	if !st.HasAccessToken {
+		return doctor.StatusFail, "no access token stored; run 'seedfast login'"
+	}
+	expiry := "no expiry"
+	if !st.ExpiresAt.IsZero() {
+		expiry = "expires " + st.ExpiresAt.Local().Format("2006-01-02 15:04")
+		if st.Expired(time.Now()) {
+			if !st.HasRefreshToken {
+				return doctor.StatusFail, "access token expired " + st.ExpiresAt.Local().Format("2006-01-02 15:04") + " and no refresh token; run 'seedfast login'"
+			}
+			expiry = "expired, will be refreshed on next use"
+		}
+	}
+	if m == nil {
+		return doctor.StatusWarn, expiry + "; not verified online (manifest unavailable)"
+	}
+
+	token, err := auth.NewService(m.HTTPBaseURL(), m.HTTP).GetAccessToken(ctx)
+	if err != nil {
+		return doctor.StatusFail, maskDetail(err)
+	}
+	if _, err := backend.New(m.HTTPBaseURL(), m.HTTP).GetMe(ctx, token); err != nil {
+		if errors.Is(err, backend.ErrUnauthorized) {
+			if st.HasRefreshToken {
+				return doctor.StatusWarn, "access token rejected by the backend; it will be refreshed on next use"
+			}
+			return doctor.StatusFail, "access token rejected by the backend; run 'seedfast login'"
+		}
+		return doctor.StatusWarn, expiry + "; not verified online: " + maskDetail(err)
+	}
+	return doctor.StatusPass, expiry + ", accepted by the backend"
+}
+
+// checkDatabase resolves the configured connection like 'seedfast seed' does
+// and runs the connection diagnostics of 'seedfast connect --test'.
+func checkDatabase(ctx context.Context) (doctor.Status, string) {
+	rawDSN, err := resolveConnectionDSN("")
+	if err != nil {
+		return doctor.StatusFail, maskDetail(err)
+	}
+	if rawDSN == "" {
+		return doctor.StatusFail, "no database connection configured; run 'seedfast connect'"
+	}
+	normalizedDSN, err := dsn.Parse(rawDSN)
+	if err != nil {
+		return doctor.StatusFail, maskDetail(err)
+	}
+	target := deriveDBName(normalizedDSN)
+
+	if dsn.DetectDBType(normalizedDSN) != dsn.DBTypePostgreSQL {
+		exec, err := sqlexec.Open(ctx, normalizedDSN)
+		if err != nil {
+			return doctor.StatusFail, fmt.Sprintf("%s: %s", target, maskDetail(err))
+		}
+		exec.Close()
+		return doctor.StatusPass, fmt.Sprintf("%s reachable (%s)", target, dsn.DetectDBType(normalizedDSN))
+	}
+
+	pool, err := pgxpool.New(ctx, normalizedDSN)
+	if err != nil {
+		return doctor.StatusFail, maskDetail(err)
+	}
+	defer pool.Close()
+	var allowSchema func(string) bool
+	if cfg, err := loadConfig(); err == nil {
+		allowSchema = cfg.Schemas.AllowsSchema
+	}
+	report, err := diagnose.Inspect(ctx, pool, allowSchema)
+	if err != nil {
+		detail := maskDetail(err)
+		info, _ := dsn.ParseInfo(normalizedDSN)
+		if fixes := diagnose.Fixes(err, info); len(fixes) > 0 {
+			detail += "; " + fixes[0]
+		}
+		return doctor.StatusFail, detail
+	}
+	tables := 0
+	for _, s := range report.Schemas {
+		tables += s.Tables
+	}
+	detail := fmt.Sprintf("%s on PostgreSQL %s as %s, %d tables in %d schemas", report.Database, report.ServerVersion, report.Role, tables, len(report.Schemas))
+	if warnings := report.Warnings(); len(warnings) > 0 {
+		detail += "; " + warnings[0]
+		if len(warnings) > 1 {
+			detail += fmt.Sprintf(" (+%d more, see 'seedfast connect --test')", len(warnings)-1)
+		}
+		return doctor.StatusWarn, detail
+	}
+	return doctor.StatusPass, detail
+}
+
+// printDoctorReport renders the report as a table.
+func printDoctorReport(r *doctor.Report) {
+	labels := map[doctor.Status]string{
+		doctor.StatusPass: pterm.Green("✅ pass"),
+		doctor.StatusWarn: pterm.Yellow("⚠️  warn"),
+		doctor.StatusFail: pterm.Red("❌ fail"),
+		doctor.StatusSkip: pterm.Gray("–  skip"),
+	}
+	data := pterm.TableData{{"Check", "Status", "Detail"}}
+	for _, res := range r.Results {
+		data = append(data, []string{res.Name, labels[res.Status], res.Detail})
+	}
+	pterm.Println()
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	pterm.Println()
+	pterm.Printf("seedfast %s (%s/%s)\n", r.CLIVersion, r.OS, r.Arch)
+}
+
+// maskDetail masks secrets in err and folds it onto one line for the table.
+func maskDetail(err error) string {
+	return strings.Join(strings.Fields(logging.Mask(err.Error())), " ")
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"seedfast/cli/internal/dsn"
+	"seedfast/cli/internal/keychain"
+)
+
+// resolveRawDSN returns the configured database connection string.
+// SEEDFAST_DSN takes precedence over DATABASE_URL, which takes precedence over the
+// default connection stored in the OS keychain by 'seedfast connect', which takes
+// precedence over the libpq PG* environment variables. Returns an empty string when
+// nothing is configured.
+func resolveRawDSN() string {
+	if env := os.Getenv("SEEDFAST_DSN"); strings.TrimSpace(env) != "" {
+		return strings.TrimSpace(env)
+	}
+	if env := os.Getenv("DATABASE_URL"); strings.TrimSpace(env) != "" {
+		return strings.TrimSpace(env)
+	}
+	if km, err := keychain.GetManager(); err == nil {
+		if v, err := km.LoadDBDSN(); err == nil && strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	// Finally, the libpq environment (PGSERVICE, PGHOST, PGDATABASE, ...)
+	return dsn.EnvironmentDSN()
+}
+
+// resolveConnectionDSN returns the DSN of the named connection profile. An
+// explicitly requested profile takes precedence over SEEDFAST_DSN and
+// DATABASE_URL. When name is empty, the environment is checked first, then the
+// connection named in seedfast.yaml or SEEDFAST_CONNECTION, then the default
+// connection stored in the OS keychain.
+func resolveConnectionDSN(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		for _, key := range []string{"SEEDFAST_DSN", "DATABASE_URL"} {
+			if env := strings.TrimSpace(os.Getenv(key)); env != "" {
+				return env, nil
+			}
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			return "", err
+		}
+		if cfg.Connection == "" {
+			return resolveRawDSN(), nil
+		}
+		name = cfg.Connection
+	}
+	km, err := keychain.GetManager()
+	if err != nil {
+		return "", fmt.Errorf("secure storage unavailable: %w", err)
+	}
+	v, err := km.LoadProfileDSN(name)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(v), nil
+}
+
+// requirePostgres rejects targets other than PostgreSQL for commands that rely
+// on PostgreSQL-specific features such as TRUNCATE ... RESTART IDENTITY.
+func requirePostgres(normalizedDSN, command string) error {
+	if t := dsn.DetectDBType(normalizedDSN); t != dsn.DBTypePostgreSQL {
+		return fmt.Errorf("'seedfast %s' supports PostgreSQL databases only (target is %s)", command, t)
+	}
+	return nil
+}
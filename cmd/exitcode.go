@@ -0,0 +1,46 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import "errors"
+
+// Process exit codes for scripts. Failures without a specific code exit with
+// exitFailure.
+const (
+	exitFailure = 1
+	// exitUsage: invalid flags or input, such as a missing or malformed DSN
+	exitUsage = 2
+	// exitNotLoggedIn: the command requires 'seedfast login'
+	exitNotLoggedIn = 3
+	// exitConnection: the database could not be reached or rejected the login
+	exitConnection = 4
+	// exitStorage: credentials could not be read from or written to secure storage
+	exitStorage = 5
+)
+
+// exitCodeError attaches a process exit code to an error.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string { return e.err.Error() }
+func (e *exitCodeError) Unwrap() error { return e.err }
+
+// withExitCode wraps err so that the process exits with code when it reaches Execute.
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitCodeError{code: code, err: err}
+}
+
+// exitCode returns the exit code for an error returned by a command.
+func exitCode(err error) int {
+	var e *exitCodeError
+	if errors.As(err, &e) {
+		return e.code
+	}
+	return exitFailure
+}
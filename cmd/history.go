@@ -0,0 +1,212 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"seedfast/cli/internal/history"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyJSON  bool
+	historyLimit int
+)
+
+// historyCmd lists the seeding sessions recorded on this machine.
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Browse and compare seeding runs recorded on this machine",
+	Long: `The history command lists the seeding runs recorded on this machine, most recent first.
+
+Every 'seedfast seed' run is recorded under the user config directory with its start and
+end time, the masked connection string, the proposed plan, the answers given to the
+backend's questions, the outcome of every table and the CLI version.
+
+Use 'seedfast history show <id>' to inspect a run and 'seedfast history diff <a> <b>'
+to compare two runs. An id may be shortened to any unique prefix, or given as "latest".`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if historyLimit <= 0 {
+			return withExitCode(exitUsage, errors.New("--limit must be positive"))
+		}
+		records, err := history.List()
+		if err != nil {
+			return fmt.Errorf("read local history: %w", err)
+		}
+		if len(records) > historyLimit {
+			records = records[:historyLimit]
+		}
+		if historyJSON {
+			if records == nil {
+				records = []*history.Record{}
+			}
+			return writeJSON(records)
+		}
+		if len(records) == 0 {
+			pterm.Println("No seeding runs recorded yet. Run 'seedfast seed' to start one.")
+			return nil
+		}
+		data := pterm.TableData{{"ID", "Started", "Database", "Planned", "Done", "Failed", "Duration", "Status"}}
+		for _, r := range records {
+			done, failed := countResults(r)
+			data = append(data, []string{
+				r.ID,
+				r.StartedAt.Local().Format("2006-01-02 15:04"),
+				r.Database,
+				fmt.Sprint(len(r.PlanTables)),
+				fmt.Sprint(done),
+				fmt.Sprint(failed),
+				r.Duration().String(),
+				r.Status,
+			})
+		}
+		return pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	},
+}
+
+// historyShowCmd prints the details of one recorded run.
+var historyShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show the plan, answers and table outcomes of a recorded run",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r, err := findHistory(args[0])
+		if err != nil {
+			return err
+		}
+		if historyJSON {
+			return writeJSON(r)
+		}
+
+		pterm.DefaultSection.Println("Session " + r.ID)
+		pterm.Printf("Started:      %s\n", r.StartedAt.Local().Format("2006-01-02 15:04:05"))
+		pterm.Printf("Duration:     %s\n", r.Duration())
+		pterm.Printf("Database:     %s\n", r.Database)
+		pterm.Printf("Connection:   %s\n", r.DSN)
+		pterm.Printf("Status:       %s\n", r.Status)
+		pterm.Printf("CLI version:  %s\n", r.CLIVersion)
+		if r.Rows > 0 {
+			pterm.Printf("Rows written: %d\n", r.Rows)
+		}
+		if r.Error != "" {
+			pterm.Printf("Error:        %s\n", r.Error)
+		}
+
+		if len(r.PlanTables) > 0 {
+			pterm.DefaultSection.WithLevel(2).Println("Plan")
+			var items []pterm.BulletListItem
+			for _, t := range r.PlanTables {
+				items = append(items, pterm.BulletListItem{Level: 0, Text: t})
+			}
+			_ = pterm.DefaultBulletList.WithItems(items).Render()
+		}
+		if len(r.Answers) > 0 {
+			pterm.DefaultSection.WithLevel(2).Println("Answers")
+			for _, a := range r.Answers {
+				question := a.Question
+				if question == "" {
+					question = "Do you agree with this seeding scope?"
+				}
+				answer := a.Answer
+				if answer == "" {
+					answer = "(accepted)"
+				}
+				if a.Source == history.AnswerConfig {
+					answer += pterm.NewStyle(pterm.FgGray).Sprint("  (from configuration)")
+				}
+				pterm.Println(pterm.NewStyle(pterm.FgYellow).Sprint(question))
+				pterm.Println("  → " + answer)
+			}
+		}
+		if len(r.Results) > 0 {
+			pterm.DefaultSection.WithLevel(2).Println("Tables")
+			data := pterm.TableData{{"Table", "Status", "Reason"}}
+			for _, res := range r.Results {
+				data = append(data, []string{res.Table, res.Status, res.Reason})
+			}
+			return pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+		}
+		return nil
+	},
+}
+
+// historyDiffCmd compares two recorded runs.
+var historyDiffCmd = &cobra.Command{
+	Use:   "diff <a> <b>",
+	Short: "Compare two recorded runs",
+	Long: `The diff command compares two recorded runs and lists what changed from the first to
+the second: connection, status, duration, rows written, the state of every planned or
+started table and the answers given.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		a, err := findHistory(args[0])
+		if err != nil {
+			return err
+		}
+		b, err := findHistory(args[1])
+		if err != nil {
+			return err
+		}
+		changes := history.Diff(a, b)
+		if historyJSON {
+			if changes == nil {
+				changes = []history.Change{}
+			}
+			return writeJSON(changes)
+		}
+		if len(changes) == 0 {
+			pterm.Printf("No differences between %s and %s.\n", a.ID, b.ID)
+			return nil
+		}
+		data := pterm.TableData{{"Field", a.ID, b.ID}}
+		for _, c := range changes {
+			data = append(data, []string{c.Field, c.Old, c.New})
+		}
+		return pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.AddCommand(historyShowCmd)
+	historyCmd.AddCommand(historyDiffCmd)
+	historyCmd.PersistentFlags().BoolVar(&historyJSON, "json", false, "Print the output as JSON")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "Maximum number of runs to list")
+}
+
+// findHistory resolves a session id, unique id prefix or "latest" to a record.
+func findHistory(id string) (*history.Record, error) {
+	r, err := history.Find(id)
+	if err != nil {
+		return nil, withExitCode(exitUsage, err)
+	}
+	return r, nil
+}
+
+// countResults returns the number of done and failed tables of a record.
+func countResults(r *history.Record) (done, failed int) {
+	for _, res := range r.Results {
+		switch res.Status {
+		case history.TableDone:
+			done++
+		case history.TableFailed:
+			failed++
+		}
+	}
+	return done, failed
+}
+
+// writeJSON prints v to stdout as indented JSON.
+func writeJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
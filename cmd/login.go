@@ -220,16 +220,10 @@ func openBrowser(url string) {
 
 // showLoginGreeting displays a friendly greeting message with the user's email after login
 func showLoginGreeting(ctx context.Context, svc *auth.Service) {
-	// Try to get user data with email
-	userData, err := svc.GetUserData(ctx)
-	if err == nil && userData != nil {
-		if email, ok := userData["email"].(string); ok && email != "" {
-			fmt.Println(getRandomLoginGreeting(email))
-			return
-		}
-		// Fallback to user_id
-		if userID, ok := userData["user_id"].(string); ok && userID != "" {
-			fmt.Println(getRandomLoginGreeting(userID))
+	// Try to get user data with email, falling back to the user ID
+	if user, err := svc.GetUserData(ctx); err == nil {
+		if id := user.Identifier(); id != "" {
+			fmt.Println(getRandomLoginGreeting(id))
 			return
 		}
 	}
@@ -21,8 +21,9 @@ var (
 // It shows the currently authenticated account information by validating the current
 // session with the backend service.
 var meCmd = &cobra.Command{
-	Use:   "me",
-	Short: "Show current authenticated account",
+	Use:     "me",
+	Aliases: []string{"whoami"},
+	Short:   "Show current authenticated account",
 	Long: `The me command displays information about the currently authenticated account.
 It validates the current session by checking with the backend service and shows
 the account identifier if authentication is valid.
@@ -68,21 +69,9 @@ other commands that require authentication.`,
 
 		svc := auth.NewService(m.HTTPBaseURL(), m.HTTP)
 
-		// Try to get full user data with email
-		userData, err := svc.GetUserData(ctx)
-		if err == nil && userData != nil {
-			// Try to extract email
-			if email, ok := userData["email"].(string); ok && email != "" {
-				fmt.Println(getMePhrase(email))
-				return nil
-			}
-			// Fallback to user_id
-			if userID, ok := userData["user_id"].(string); ok && userID != "" {
-				fmt.Println(getMePhrase(userID))
-				return nil
-			}
-			// Fallback to id
-			if id, ok := userData["id"].(string); ok && id != "" {
+		// Try to get full user data: email, then user_id, then id
+		if user, err := svc.GetUserData(ctx); err == nil {
+			if id := user.Identifier(); id != "" {
 				fmt.Println(getMePhrase(id))
 				return nil
 			}
@@ -0,0 +1,219 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+
+	"seedfast/cli/internal/dsn"
+	"seedfast/cli/internal/history"
+	"seedfast/cli/internal/logging"
+	"seedfast/cli/internal/safety"
+	"seedfast/cli/internal/sqlexec"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var (
+	resetCascade           bool
+	resetDryRun            bool
+	resetYes               bool
+	resetIKnowProduction   bool
+	resetProductionPattern string
+)
+
+// errTablesReferenced is returned when other tables still reference the tables to reset.
+var errTablesReferenced = errors.New("tables are referenced by other tables; use --cascade")
+
+// resetCmd represents the reset command for wiping seeded data.
+// Without arguments it resets the tables written by the last seeding session
+// recorded for the current database.
+var resetCmd = &cobra.Command{
+	Use:   "reset [table...]",
+	Short: "Truncate seeded tables and restart their identities",
+	Long: `The reset command empties the given tables with TRUNCATE ... RESTART IDENTITY.
+Without arguments it resets the tables written by the last 'seedfast seed' session
+against the current database on the same server.
+
+Table names are resolved on the server, so unqualified names follow the connection's
+search_path; reset refuses to run if any of them does not exist.
+
+Tables outside the list that reference it through foreign keys cannot be left
+behind: reset lists them and refuses to run unless --cascade is given, in which
+case they are emptied as well. Use --dry-run to preview the statement.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			pterm.Println("❌ " + err.Error())
+			pterm.Println("   Run 'seedfast config validate' for details.")
+			return err
+		}
+
+		rawDSN, err := resolveConnectionDSN("")
+		if err != nil {
+			pterm.Println("❌ " + err.Error())
+			return err
+		}
+		if rawDSN == "" {
+			pterm.Println("⚠️  No database connection configured.")
+			pterm.Println("   Please run 'seedfast connect' to configure your database.")
+			return nil
+		}
+		normalizedDSN, err := dsn.Parse(rawDSN)
+		if err != nil {
+			pterm.Println("❌ Invalid database connection string.")
+			return err
+		}
+		if err := requirePostgres(normalizedDSN, "reset"); err != nil {
+			pterm.Println("❌ " + err.Error())
+			return err
+		}
+		dbName := deriveDBName(normalizedDSN)
+
+		tables := args
+		source := "command line"
+		if len(tables) == 0 {
+			last, err := history.Latest(logging.Mask(normalizedDSN))
+			if err != nil {
+				return err
+			}
+			if last == nil {
+				pterm.Printf("No seeding session recorded for database %q on this server.\n", dbName)
+				pterm.Println("   Pass the tables to reset explicitly: seedfast reset <table>...")
+				return nil
+			}
+			tables = last.Tables
+			source = "session " + last.ID
+		}
+
+		pool, err := pgxpool.New(cmd.Context(), normalizedDSN)
+		if err != nil {
+			pterm.Printf("❌ Failed to connect to database\n")
+			pterm.Println(logging.PresentError("", err))
+			return err
+		}
+		defer pool.Close()
+
+		inspector := sqlexec.NewSchemaInspector(pool)
+		tables, err = inspector.ResolveTables(cmd.Context(), tables)
+		if err != nil {
+			pterm.Println(logging.PresentError("❌ Cannot reset", err))
+			return err
+		}
+		fks, err := inspector.GetForeignKeys(cmd.Context())
+		if err != nil {
+			pterm.Println(logging.PresentError("❌ Failed to inspect foreign keys", err))
+			return err
+		}
+		// Children first, so the preview reads in the order rows are logically removed
+		order := sqlexec.DependencyOrder(tables, fks)
+		for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+			order[i], order[j] = order[j], order[i]
+		}
+		dependents := sqlexec.DependentTables(tables, fks)
+
+		pterm.Println()
+		pterm.Println(pterm.NewStyle(pterm.FgLightCyan).Sprint("→ Database:   ") + pterm.NewStyle(pterm.FgCyan, pterm.Bold).Sprint(dbName))
+		pterm.Println(pterm.NewStyle(pterm.FgLightCyan).Sprint("→ Source:     ") + source)
+		pterm.Println()
+		pterm.Println("The following tables will be truncated:")
+		var items []pterm.BulletListItem
+		for _, t := range order {
+			items = append(items, pterm.BulletListItem{Level: 0, Text: t})
+		}
+		_ = pterm.DefaultBulletList.WithItems(items).Render()
+
+		if len(dependents) > 0 {
+			if resetCascade {
+				pterm.Println("These dependent tables reference them and will be emptied too (CASCADE):")
+			} else {
+				pterm.Println("These dependent tables reference them through foreign keys:")
+			}
+			items = items[:0]
+			for _, t := range dependents {
+				items = append(items, pterm.BulletListItem{Level: 0, Text: t})
+			}
+			_ = pterm.DefaultBulletList.WithItems(items).Render()
+		}
+
+		stmt := truncateStatement(order, resetCascade)
+		pterm.Println(pterm.NewStyle(pterm.FgGray).Sprint(stmt))
+		pterm.Println()
+
+		blocked := len(dependents) > 0 && !resetCascade
+		if resetDryRun {
+			if blocked {
+				pterm.Println("⚠️  Without --cascade this reset would be refused because of the dependent tables.")
+			}
+			pterm.Println("Dry run: no changes were made.")
+			return nil
+		}
+		if blocked {
+			pterm.Println("❌ Cannot truncate tables that are still referenced. Add the dependent tables")
+			pterm.Println("   to the list, or re-run with --cascade to empty them as well.")
+			return errTablesReferenced
+		}
+
+		policy, err := safetyPolicy(cfg.Safety, resetProductionPattern)
+		if err != nil {
+			return err
+		}
+		guard := safety.NewGuard(safety.CheckDSN(policy, rawDSN))
+		guard.Update(func(r *safety.Report) { _ = safety.CheckServer(cmd.Context(), policy, pool, r) })
+		if guard.NeedsConfirmation() {
+			if err := confirmProductionTarget(guard, resetIKnowProduction, "seedfast reset"); err != nil {
+				return err
+			}
+		}
+
+		if !resetYes {
+			pterm.Print("Type 'yes' to continue: ")
+			ans, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if !strings.EqualFold(strings.TrimSpace(ans), "yes") {
+				pterm.Println("Reset cancelled. No changes were made.")
+				return nil
+			}
+		}
+
+		if _, err := pool.Exec(cmd.Context(), stmt); err != nil {
+			pterm.Println(logging.PresentError("❌ Reset failed, no changes were made", err))
+			return err
+		}
+		total := len(order)
+		if resetCascade {
+			total += len(dependents)
+		}
+		pterm.Printf("✅ Truncated %d tables\n", total)
+		return nil
+	},
+}
+
+// truncateStatement builds a single TRUNCATE statement for the given tables.
+// Identities are always restarted so the next seed starts from clean sequences.
+func truncateStatement(tables []string, cascade bool) string {
+	quoted := make([]string, len(tables))
+	for i, t := range tables {
+		quoted[i] = sqlexec.QuoteTableName(t)
+	}
+	stmt := "TRUNCATE " + strings.Join(quoted, ", ") + " RESTART IDENTITY"
+	if cascade {
+		stmt += " CASCADE"
+	}
+	return stmt
+}
+
+func init() {
+	rootCmd.AddCommand(resetCmd)
+	resetCmd.Flags().BoolVar(&resetCascade, "cascade", false, "Also truncate tables that reference the given tables")
+	resetCmd.Flags().BoolVar(&resetDryRun, "dry-run", false, "Show the affected tables and statement without executing it")
+	resetCmd.Flags().BoolVarP(&resetYes, "yes", "y", false, "Skip the confirmation prompt")
+	resetCmd.Flags().BoolVar(&resetIKnowProduction, "i-know-this-is-production", false, "Allow resetting a database that looks like production (requires typing the database name)")
+	resetCmd.Flags().StringVar(&resetProductionPattern, "production-pattern", "", "Regular expression matched against DSN host and database name to flag production targets (env: SEEDFAST_PRODUCTION_PATTERN)")
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"seedfast/cli/internal/dsn"
+	"seedfast/cli/internal/history"
+	"seedfast/cli/internal/logging"
+	"seedfast/cli/internal/safety"
+	"seedfast/cli/internal/snapshot"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var (
+	restoreYes               bool
+	restoreIKnowProduction   bool
+	restoreProductionPattern string
+)
+
+// restoreCmd represents the restore command for rolling tables back to a snapshot.
+// Snapshots are taken by 'seedfast seed --snapshot' before the first write.
+var restoreCmd = &cobra.Command{
+	Use:   "restore [snapshot-id]",
+	Short: "Restore tables from a snapshot taken before seeding",
+	Long: `The restore command truncates the tables captured in a snapshot and loads the
+saved rows back, in foreign-key order, inside a single transaction.
+
+Snapshots are created by 'seedfast seed --snapshot'. Run 'seedfast restore' without
+arguments to list available snapshots. A snapshot is only restored into the database
+it was taken from, on the same host and port.`,
+	Args: cobra.MaximumNArgs(1),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return listSnapshots()
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			pterm.Println("❌ " + err.Error())
+			pterm.Println("   Run 'seedfast config validate' for details.")
+			return err
+		}
+
+		snap, err := snapshot.Load(args[0])
+		if err != nil {
+			pterm.Println("❌ " + err.Error())
+			pterm.Println("   Run 'seedfast restore' to list available snapshots.")
+			return err
+		}
+
+		rawDSN, err := resolveConnectionDSN("")
+		if err != nil {
+			pterm.Println("❌ " + err.Error())
+			return err
+		}
+		if rawDSN == "" {
+			pterm.Println("⚠️  No database connection configured.")
+			pterm.Println("   Please run 'seedfast connect' to configure your database.")
+			return nil
+		}
+		normalizedDSN, err := dsn.Parse(rawDSN)
+		if err != nil {
+			pterm.Println("❌ Invalid database connection string.")
+			return err
+		}
+		if err := requirePostgres(normalizedDSN, "restore"); err != nil {
+			pterm.Println("❌ " + err.Error())
+			return err
+		}
+		dbName := deriveDBName(normalizedDSN)
+		if maskedDSN := logging.Mask(normalizedDSN); !history.SameTarget(snap.DSN, maskedDSN) {
+			pterm.Printf("❌ Snapshot %s was taken from %s, but the current connection targets %s.\n", snap.ID, snap.DSN, maskedDSN)
+			return fmt.Errorf("snapshot target mismatch")
+		}
+
+		pool, err := pgxpool.New(cmd.Context(), normalizedDSN)
+		if err != nil {
+			pterm.Printf("❌ Failed to connect to database\n")
+			pterm.Println(logging.PresentError("", err))
+			return err
+		}
+		defer pool.Close()
+
+		order, err := snapshot.RestoreOrder(cmd.Context(), pool, snap)
+		if err != nil {
+			pterm.Println(logging.PresentError("❌ Failed to inspect foreign keys", err))
+			return err
+		}
+
+		pterm.Println()
+		pterm.Println(pterm.NewStyle(pterm.FgLightCyan).Sprint("→ Database:   ") + pterm.NewStyle(pterm.FgCyan, pterm.Bold).Sprint(dbName))
+		pterm.Println(pterm.NewStyle(pterm.FgLightCyan).Sprint("→ Snapshot:   ") + snap.ID + " (" + snap.CreatedAt.Local().Format("2006-01-02 15:04:05") + ")")
+		pterm.Println()
+		pterm.Println("The following tables will be truncated and restored, in this order:")
+		var items []pterm.BulletListItem
+		for _, t := range order {
+			items = append(items, pterm.BulletListItem{Level: 0, Text: t})
+		}
+		_ = pterm.DefaultBulletList.WithItems(items).Render()
+
+		policy, err := safetyPolicy(cfg.Safety, restoreProductionPattern)
+		if err != nil {
+			return err
+		}
+		guard := safety.NewGuard(safety.CheckDSN(policy, rawDSN))
+		guard.Update(func(r *safety.Report) { _ = safety.CheckServer(cmd.Context(), policy, pool, r) })
+		if guard.NeedsConfirmation() {
+			if err := confirmProductionTarget(guard, restoreIKnowProduction, "seedfast restore "+snap.ID); err != nil {
+				return err
+			}
+		}
+
+		if !restoreYes {
+			pterm.Print("Type 'yes' to continue: ")
+			ans, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if !strings.EqualFold(strings.TrimSpace(ans), "yes") {
+				pterm.Println("Restore cancelled. No changes were made.")
+				return nil
+			}
+		}
+
+		stop := startInlineSpinner(os.Stdout, "restoring snapshot", []string{"|", "/", "-", "\\"}, 120*time.Millisecond)
+		err = snapshot.Restore(cmd.Context(), pool, snap)
+		stop()
+		if err != nil {
+			pterm.Println(logging.PresentError("❌ Restore failed, no changes were made", err))
+			return err
+		}
+
+		pterm.Printf("✅ Restored %d tables from snapshot %s\n", len(order), snap.ID)
+		return nil
+	},
+}
+
+// listSnapshots prints all locally stored snapshots, newest first.
+func listSnapshots() error {
+	snaps, err := snapshot.List()
+	if err != nil {
+		return err
+	}
+	if len(snaps) == 0 {
+		pterm.Println("No snapshots found. Create one with: seedfast seed --snapshot")
+		return nil
+	}
+	data := pterm.TableData{{"ID", "Created", "Database", "Tables"}}
+	for _, s := range snaps {
+		data = append(data, []string{s.ID, s.CreatedAt.Local().Format("2006-01-02 15:04:05"), s.Database, fmt.Sprint(len(s.Tables))})
+	}
+	return pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+	restoreCmd.Flags().BoolVarP(&restoreYes, "yes", "y", false, "Skip the confirmation prompt")
+	restoreCmd.Flags().BoolVar(&restoreIKnowProduction, "i-know-this-is-production", false, "Allow restoring into a database that looks like production (requires typing the database name)")
+	restoreCmd.Flags().StringVar(&restoreProductionPattern, "production-pattern", "", "Regular expression matched against DSN host and database name to flag production targets (env: SEEDFAST_PRODUCTION_PATTERN)")
+}
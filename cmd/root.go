@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"os"
 
+	"seedfast/cli/internal/auth"
 	"seedfast/cli/internal/backend"
 	"seedfast/cli/internal/manifest"
 
@@ -19,7 +20,9 @@ import (
 )
 
 var (
-	showVersion bool
+	showVersion    bool
+	tokenFile      string
+	manifestSource string
 )
 
 // rootCmd represents the base command when called without any subcommands.
@@ -71,10 +74,24 @@ var rootCmd = &cobra.Command{
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
 
 func init() {
 	rootCmd.Flags().BoolVar(&showVersion, "version", false, "Show CLI and backend version information")
+	rootCmd.PersistentFlags().StringVar(&tokenFile, "token-file", "", "Read credentials from a token file instead of the OS keychain (env: SEEDFAST_TOKEN_FILE)")
+	rootCmd.PersistentFlags().StringVar(&manifestSource, "manifest", "", "Endpoint manifest URL or local file, e.g. for staging or self-hosted backends (env: SEEDFAST_MANIFEST_URL)")
+	// Invalid flags exit with the usage code in every subcommand
+	rootCmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		return withExitCode(exitUsage, err)
+	})
+	cobra.OnInitialize(func() {
+		if tokenFile != "" {
+			auth.SetTokenFile(tokenFile)
+		}
+		if manifestSource != "" {
+			manifest.SetSource(manifestSource)
+		}
+	})
 }
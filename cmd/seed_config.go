@@ -0,0 +1,107 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"seedfast/cli/internal/config"
+	"seedfast/cli/internal/dsn"
+	"seedfast/cli/internal/sqlexec"
+)
+
+// seedSummary is the machine-readable result printed by 'seedfast seed --output json'.
+type seedSummary struct {
+	SessionID     string            `json:"session_id"`
+	Database      string            `json:"database"`
+	Status        string            `json:"status"`
+	DurationMS    int64             `json:"duration_ms"`
+	TablesSeeded  []string          `json:"tables_seeded"`
+	FailedTables  map[string]string `json:"failed_tables,omitempty"`
+	TrackedRows   int               `json:"tracked_rows"`
+	TrackingError string            `json:"tracking_error,omitempty"`
+	Snapshot      string            `json:"snapshot,omitempty"`
+	RowTargets    []rowTargetCheck  `json:"row_targets,omitempty"`
+}
+
+// writeSeedSummary writes s as a single JSON line.
+func writeSeedSummary(w io.Writer, s seedSummary) {
+	if s.TablesSeeded == nil {
+		s.TablesSeeded = []string{}
+	}
+	_ = json.NewEncoder(w).Encode(s)
+}
+
+// configuredAnswer returns the automatic reply to an ask_human question.
+// "yes" (or "y") accepts and "no" rejects every question. Any other text is
+// sent as feedback once; later questions are asked interactively so that the
+// same feedback cannot loop forever. ok is false when the user must be asked.
+func configuredAnswer(answer string, answered *bool) (reply string, ok bool) {
+	switch strings.ToLower(answer) {
+	case "":
+		return "", false
+	case "yes", "y":
+		return "", true
+	case "no", "n":
+		return "no", true
+	}
+	if *answered {
+		return "", false
+	}
+	*answered = true
+	return answer, true
+}
+
+// checkWriteScope rejects a write task unless every table it writes lies
+// within the configured schemas and tables. Unqualified tables resolve to the
+// first schema of taskSchema, the search_path the task runs with, or public.
+// While a scope is configured, statements whose targets cannot all be
+// determined are rejected.
+func checkWriteScope(schemas config.Schemas, tables config.Tables, sql, taskSchema string, dbType dsn.DBType) error {
+	if len(schemas.Include) == 0 && len(schemas.Exclude) == 0 && len(tables.Include) == 0 && len(tables.Exclude) == 0 {
+		return nil
+	}
+	targets, ok := sqlexec.WriteTargets(sql, dbType)
+	if !ok {
+		return errors.New("write blocked: cannot determine the tables this statement writes, which is required while a seeding scope is configured")
+	}
+	for _, target := range targets {
+		schema, table := splitTableName(sqlexec.QualifyTableNameIn(target, taskSchema), "")
+		if !schemas.AllowsSchema(schema) {
+			return fmt.Errorf("write blocked: schema %q is outside the configured schemas", schema)
+		}
+		if !tables.AllowsTable(schema, table) {
+			return fmt.Errorf("write blocked: table %q is outside the configured tables", schema+"."+table)
+		}
+	}
+	return nil
+}
+
+// outOfScope returns the planned tables that writes would be blocked for.
+func outOfScope(schemas config.Schemas, tables config.Tables, planned []string) []string {
+	var out []string
+	for _, name := range planned {
+		schema, table := splitTableName(name, "")
+		if !schemas.AllowsSchema(schema) || !tables.AllowsTable(schema, table) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// splitTableName splits a possibly qualified table name. Unqualified names
+// belong to defaultSchema, or public when it is empty.
+func splitTableName(name, defaultSchema string) (schema, table string) {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	if defaultSchema == "" {
+		defaultSchema = "public"
+	}
+	return defaultSchema, name
+}
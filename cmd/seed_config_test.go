@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"testing"
+
+	"seedfast/cli/internal/config"
+	"seedfast/cli/internal/dsn"
+)
+
+func TestCheckWriteScope(t *testing.T) {
+	billing := config.Schemas{Include: []string{"billing"}}
+	tests := []struct {
+		name       string
+		schemas    config.Schemas
+		tables     config.Tables
+		sql        string
+		taskSchema string
+		allowed    bool
+	}{
+		{"no scope", config.Schemas{}, config.Tables{}, `ALTER TABLE audit.events DISABLE TRIGGER ALL`, "", true},
+		{"qualified in scope", billing, config.Tables{}, `INSERT INTO billing.invoices VALUES (1)`, "", true},
+		{"unqualified defaults to public", billing, config.Tables{}, `INSERT INTO invoices VALUES (1)`, "", false},
+		{"unqualified in task schema", billing, config.Tables{}, `INSERT INTO invoices VALUES (1)`, "billing", true},
+		{"write in CTE", billing, config.Tables{}, `WITH d AS (DELETE FROM audit.events RETURNING id) INSERT INTO billing.log SELECT id FROM d`, "", false},
+		{"INSERT SELECT", billing, config.Tables{}, `WITH x AS (SELECT 1) INSERT INTO public.users SELECT * FROM x`, "", false},
+		{"second TRUNCATE target", billing, config.Tables{}, `TRUNCATE billing.invoices, public.users`, "", false},
+		{"all TRUNCATE targets in scope", billing, config.Tables{}, `TRUNCATE billing.invoices, billing.lines`, "", true},
+		{"second statement", billing, config.Tables{}, `INSERT INTO billing.a VALUES (1); INSERT INTO public.b VALUES (1)`, "", false},
+		{"undeterminable target", billing, config.Tables{}, `DO $$ BEGIN DELETE FROM public.users; END $$`, "", false},
+		{"DDL", billing, config.Tables{}, `ALTER TABLE billing.invoices DISABLE TRIGGER ALL`, "", false},
+		{"excluded schema", config.Schemas{Exclude: []string{"audit"}}, config.Tables{}, `UPDATE audit.events SET x = 1`, "", false},
+		{"table include", config.Schemas{}, config.Tables{Include: []string{"users"}}, `INSERT INTO app.users VALUES (1)`, "", true},
+		{"table exclude glob", config.Schemas{}, config.Tables{Exclude: []string{"*_archive"}}, `INSERT INTO orders_archive VALUES (1)`, "", false},
+		{"unqualified in first search_path schema", billing, config.Tables{}, `INSERT INTO invoices VALUES (1)`, "billing, public", true},
+		{"unqualified outside first search_path schema", billing, config.Tables{}, `INSERT INTO invoices VALUES (1)`, "public, billing", false},
+		{"backslash does not end a standard literal", config.Schemas{}, config.Tables{Include: []string{"public.allowed"}}, `INSERT INTO public.allowed VALUES ('x\'); DELETE FROM public.users; --')`, "", false},
+		{"read-only task", billing, config.Tables{}, `SELECT setval('public.users_id_seq', 10)`, "", true},
+	}
+	for _, tt := range tests {
+		err := checkWriteScope(tt.schemas, tt.tables, tt.sql, tt.taskSchema, dsn.DBTypePostgreSQL)
+		if (err == nil) != tt.allowed {
+			t.Errorf("%s: checkWriteScope(%q) = %v, want allowed %v", tt.name, tt.sql, err, tt.allowed)
+		}
+	}
+}
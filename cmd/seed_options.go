@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"strings"
+
+	"seedfast/cli/internal/bridge/model"
+
+	"github.com/pterm/pterm"
+)
+
+// optionFlags names the flag behind each session option, for warnings.
+var optionFlags = map[string]string{
+	model.OptionScope:      "--schemas/--tables",
+	model.OptionRowTargets: "--rows",
+	model.OptionScale:      "--scale",
+	model.OptionLocale:     "--locale",
+	model.OptionDryRun:     "--dry-run",
+}
+
+// warnIgnoredOptions tells the user which requested options the backend will not apply.
+// Scope and dry run are still enforced locally, so they are only noted as such.
+// legacy is set when the backend predates session options and reported nothing.
+func warnIgnoredOptions(ignored []string, legacy bool) {
+	if len(ignored) == 0 {
+		return
+	}
+	var dropped, local []string
+	for _, o := range ignored {
+		name := optionFlags[o]
+		if name == "" {
+			name = o
+		}
+		if o == model.OptionScope || o == model.OptionDryRun {
+			local = append(local, name)
+			continue
+		}
+		dropped = append(dropped, name)
+	}
+	pterm.Println()
+	if legacy {
+		pterm.Warning.Println("The backend does not report which session options it supports.")
+	}
+	if len(dropped) > 0 {
+		pterm.Warning.Printf("The backend may ignore: %s\n", strings.Join(dropped, ", "))
+	}
+	if len(local) > 0 {
+		pterm.Info.Printf("Enforced locally: %s\n", strings.Join(local, ", "))
+	}
+}
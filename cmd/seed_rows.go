@@ -0,0 +1,147 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"seedfast/cli/internal/bridge/model"
+	"seedfast/cli/internal/dsn"
+	"seedfast/cli/internal/sqlexec"
+
+	"github.com/pterm/pterm"
+)
+
+// rowCounter sums the rows inserted per table, keyed by schema-qualified name.
+// It is shared by the SQL workers.
+type rowCounter struct {
+	mu   sync.Mutex
+	rows map[string]int64
+}
+
+// add records n affected rows of a successful statement. Rows count when the
+// main clause of the statement, its last write, is an INSERT or COPY, so inserts
+// after data-modifying CTEs are included; updates and deletes do not add rows.
+func (c *rowCounter) add(sql, taskSchema string, dbType dsn.DBType, n int64) {
+	if n <= 0 {
+		return
+	}
+	ops, ok := sqlexec.WriteOps(sql, dbType)
+	if !ok || len(ops) == 0 {
+		return
+	}
+	last := ops[len(ops)-1]
+	if last.Verb != "INSERT" && last.Verb != "COPY" {
+		return
+	}
+	schema, table := splitTableName(sqlexec.QualifyTableNameIn(last.Table, taskSchema), "")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rows == nil {
+		c.rows = map[string]int64{}
+	}
+	c.rows[schema+"."+table] += n
+}
+
+// written returns a copy of the per-table row counts.
+func (c *rowCounter) written() map[string]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(map[string]int64, len(c.rows))
+	for k, v := range c.rows {
+		out[k] = v
+	}
+	return out
+}
+
+// rowTargetCheck compares a row-count target with the rows actually inserted.
+type rowTargetCheck struct {
+	Table   string `json:"table"`
+	Target  int64  `json:"target"`
+	Written int64  `json:"written"`
+	Met     bool   `json:"met"`
+}
+
+// checkRowTargets compares every target with the inserted row counts, sorted
+// by table. A target is met when exactly the requested number of rows was
+// inserted.
+func checkRowTargets(targets, written map[string]int64) []rowTargetCheck {
+	var checks []rowTargetCheck
+	for table, target := range targets {
+		var n int64
+		for name, rows := range written {
+			if targetMatches(table, name) {
+				n += rows
+			}
+		}
+		checks = append(checks, rowTargetCheck{Table: table, Target: target, Written: n, Met: n == target})
+	}
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Table < checks[j].Table })
+	return checks
+}
+
+// targetMatches reports whether a row target applies to a table. Qualified
+// targets name one table; unqualified targets apply to the table name in any
+// schema.
+func targetMatches(target, table string) bool {
+	schema, name := splitTableName(table, "")
+	if strings.Contains(target, ".") {
+		return target == schema+"."+name
+	}
+	return target == name
+}
+
+// printPlanning shows the requested data volume below the proposed plan and
+// marks row targets for tables the plan does not include.
+func printPlanning(planning model.Planning, planned []string) {
+	if planning.Scale == "" && len(planning.Rows) == 0 {
+		return
+	}
+	pterm.Println()
+	if planning.Scale != "" {
+		pterm.Println(pterm.NewStyle(pterm.FgLightCyan).Sprint("→ Scale:      ") + planning.Scale)
+	}
+	if len(planning.Rows) == 0 {
+		return
+	}
+	pterm.Println(pterm.NewStyle(pterm.FgLightCyan).Sprint("→ Row targets:"))
+	for _, c := range checkRowTargets(planning.Rows, nil) {
+		line := fmt.Sprintf("  • %s: %d rows", c.Table, c.Target)
+		inPlan := len(planned) == 0
+		for _, t := range planned {
+			if targetMatches(c.Table, t) {
+				inPlan = true
+				break
+			}
+		}
+		if !inPlan {
+			line += pterm.NewStyle(pterm.FgGray).Sprint("  (not in plan)")
+		}
+		pterm.Println(line)
+	}
+}
+
+// printRowTargetChecks shows how the inserted row counts compare with the targets.
+func printRowTargetChecks(checks []rowTargetCheck) {
+	if len(checks) == 0 {
+		return
+	}
+	data := pterm.TableData{{"Table", "Target", "Inserted", ""}}
+	for _, c := range checks {
+		status := pterm.NewStyle(pterm.FgGreen).Sprint("✓")
+		switch {
+		case c.Written < c.Target:
+			status = pterm.NewStyle(pterm.FgYellow).Sprintf("%d short", c.Target-c.Written)
+		case c.Written > c.Target:
+			status = pterm.NewStyle(pterm.FgYellow).Sprintf("%d over", c.Written-c.Target)
+		}
+		data = append(data, []string{c.Table, fmt.Sprint(c.Target), fmt.Sprint(c.Written), status})
+	}
+	pterm.Println(pterm.NewStyle(pterm.FgLightCyan, pterm.Bold).Sprint("Row targets"))
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	pterm.Println()
+}
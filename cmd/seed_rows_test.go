@@ -0,0 +1,26 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"seedfast/cli/internal/dsn"
+)
+
+func TestRowCounter(t *testing.T) {
+	var c rowCounter
+	c.add(`INSERT INTO users (name) VALUES ('a'), ('b')`, "", dsn.DBTypePostgreSQL, 2)
+	c.add(`WITH u AS (SELECT id FROM public.users) INSERT INTO orders (user_id) SELECT id FROM u`, "app", dsn.DBTypePostgreSQL, 5)
+	c.add(`WITH d AS (DELETE FROM public.orders RETURNING *) INSERT INTO public.orders_archive SELECT * FROM d`, "", dsn.DBTypePostgreSQL, 3)
+	c.add(`WITH i AS (INSERT INTO public.users (name) VALUES ('c') RETURNING id) UPDATE public.orders SET user_id = 1`, "", dsn.DBTypePostgreSQL, 4)
+	c.add(`UPDATE public.users SET name = 'x'`, "", dsn.DBTypePostgreSQL, 7)
+	c.add(`INSERT INTO public.users (name) VALUES ('d')`, "", dsn.DBTypePostgreSQL, 0)
+
+	want := map[string]int64{"public.users": 2, "app.orders": 5, "public.orders_archive": 3}
+	if got := c.written(); !reflect.DeepEqual(got, want) {
+		t.Errorf("written() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"seedfast/cli/internal/config"
+	"seedfast/cli/internal/safety"
+
+	"github.com/pterm/pterm"
+)
+
+// errProductionRefused is returned when a command is stopped by the production safeguard.
+var errProductionRefused = errors.New("refusing to modify a production-like database")
+
+// safetyPolicy builds the production safeguard policy from a flag value and the
+// resolved safety settings. A non-empty flagPattern takes precedence over
+// SEEDFAST_PRODUCTION_PATTERN and the safety section of seedfast.yaml.
+func safetyPolicy(cfg config.Safety, flagPattern string) (safety.Policy, error) {
+	policy := safety.DefaultPolicy()
+	if v := cfg.MaxDatabaseBytes; v != nil {
+		policy.MaxDatabaseBytes = *v
+	}
+	if v := cfg.MaxTableRows; v != nil {
+		policy.MaxTableRows = *v
+	}
+	pattern := strings.TrimSpace(flagPattern)
+	if pattern == "" {
+		pattern = cfg.ProductionPattern
+	}
+	return policy.WithDenyPattern(pattern)
+}
+
+// confirmProductionTarget shows why the target looks like production and asks the
+// user to type the database name. Without --i-know-this-is-production it refuses
+// immediately and suggests re-running command with the flag. On success the guard
+// is marked as confirmed.
+func confirmProductionTarget(guard *safety.Guard, allowed bool, command string) error {
+	r := guard.Report()
+
+	var details strings.Builder
+	details.WriteString("The target database looks like a production system:\n\n")
+	for _, s := range r.Signals {
+		details.WriteString("  • " + s.Detail + "\n")
+	}
+	title := pterm.NewStyle(pterm.FgRed, pterm.Bold).Sprint("Production Safeguard")
+	pterm.Println(pterm.DefaultBox.WithTitle(title).WithPadding(1).Sprint(strings.TrimRight(details.String(), "\n")))
+
+	if !allowed {
+		pterm.Println("No data was written. If you really intend to modify this database, re-run with:")
+		pterm.Println("   " + command + " --i-know-this-is-production")
+		return errProductionRefused
+	}
+
+	pterm.Print(fmt.Sprintf("Type the database name (%s) to confirm: ", r.Database))
+	reader := bufio.NewReader(os.Stdin)
+	ans, _ := reader.ReadString('\n')
+	if strings.TrimSpace(ans) != r.Database || r.Database == "" {
+		pterm.Println("❌ Database name does not match. No data was written.")
+		return errProductionRefused
+	}
+	guard.Confirm()
+	pterm.Println()
+	return nil
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"seedfast/cli/internal/auth"
+	"seedfast/cli/internal/backend"
+	"seedfast/cli/internal/history"
+	"seedfast/cli/internal/manifest"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sessionsJSON  bool
+	sessionsLimit int
+	sessionsLocal bool
+)
+
+// Session sources reported by 'seedfast sessions'.
+const (
+	sourceBackend = "backend"
+	sourceLocal   = "local"
+	sourceBoth    = "both"
+)
+
+// sessionEntry is one seeding session, as recorded by the backend, by the
+// local history on this machine, or both.
+type sessionEntry struct {
+	// ID is the local session ID when known (usable with 'seedfast undo'), else the backend ID
+	ID         string    `json:"id"`
+	BackendID  string    `json:"backend_id,omitempty"`
+	Source     string    `json:"source"`
+	Database   string    `json:"database"`
+	Tables     int       `json:"tables"`
+	Rows       int64     `json:"rows"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMS int64     `json:"duration_ms"`
+	Status     string    `json:"status"`
+	Credits    int64     `json:"credits,omitempty"`
+}
+
+// sessionsCmd lists recent seeding sessions.
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "List recent seeding sessions",
+	Long: `The sessions command lists recent seeding sessions with the database, the number of
+tables and rows written, the duration and the final status.
+
+Sessions are read from the backend, which knows about runs from every machine, and
+from the local history of this machine. Sessions found in both are shown once. Use
+--local to skip the backend, e.g. when offline or not logged in.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if sessionsLimit <= 0 {
+			return withExitCode(exitUsage, errors.New("--limit must be positive"))
+		}
+		if sessionsJSON {
+			// Keep stdout for the JSON list
+			pterm.SetDefaultOutput(os.Stderr)
+		}
+		records, err := history.List()
+		if err != nil {
+			return fmt.Errorf("read local history: %w", err)
+		}
+		var remote []backend.Session
+		if !sessionsLocal {
+			remote, err = fetchSessions(cmd, sessionsLimit)
+			if err != nil {
+				pterm.Println("⚠️  Showing local sessions only: " + err.Error())
+			}
+		}
+		entries := mergeSessions(remote, records)
+		if len(entries) > sessionsLimit {
+			entries = entries[:sessionsLimit]
+		}
+
+		if sessionsJSON {
+			if entries == nil {
+				entries = []sessionEntry{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(entries)
+		}
+		if len(entries) == 0 {
+			pterm.Println("No seeding sessions yet. Run 'seedfast seed' to start one.")
+			return nil
+		}
+		data := pterm.TableData{{"ID", "Started", "Database", "Tables", "Rows", "Duration", "Status", "Source"}}
+		for _, e := range entries {
+			data = append(data, []string{
+				e.ID,
+				e.StartedAt.Local().Format("2006-01-02 15:04"),
+				e.Database,
+				fmt.Sprint(e.Tables),
+				fmt.Sprint(e.Rows),
+				(time.Duration(e.DurationMS) * time.Millisecond).Round(time.Second).String(),
+				e.Status,
+				e.Source,
+			})
+		}
+		return pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sessionsCmd)
+	sessionsCmd.Flags().BoolVar(&sessionsJSON, "json", false, "Print the sessions as JSON")
+	sessionsCmd.Flags().IntVarP(&sessionsLimit, "limit", "n", 20, "Maximum number of sessions to list")
+	sessionsCmd.Flags().BoolVar(&sessionsLocal, "local", false, "Only list sessions from the local history")
+}
+
+// fetchSessions reads recent sessions from the backend. It fails softly when
+// the user is not logged in or the backend keeps no session history.
+func fetchSessions(cmd *cobra.Command, limit int) ([]backend.Session, error) {
+	if st, err := auth.Load(); err != nil || !st.LoggedIn {
+		return nil, errors.New("not logged in")
+	}
+	m, err := manifest.GetEndpoints(cmd.Context())
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := auth.NewService(m.HTTPBaseURL(), m.HTTP).ListSessions(cmd.Context(), limit)
+	if errors.Is(err, backend.ErrNotSupported) {
+		return nil, errors.New("the backend does not keep session history")
+	}
+	return sessions, err
+}
+
+// mergeSessions combines backend and local sessions, newest first. A backend
+// session that names a local session ID is merged into the local record.
+func mergeSessions(remote []backend.Session, records []*history.Record) []sessionEntry {
+	byID := make(map[string]int, len(records))
+	entries := make([]sessionEntry, 0, len(remote)+len(records))
+	for _, r := range records {
+		byID[r.ID] = len(entries)
+		entries = append(entries, sessionEntry{
+			ID:         r.ID,
+			Source:     sourceLocal,
+			Database:   r.Database,
+			Tables:     len(r.Tables),
+			Rows:       r.Rows,
+			StartedAt:  r.StartedAt,
+			DurationMS: r.EndedAt.Sub(r.StartedAt).Milliseconds(),
+			Status:     r.Status,
+		})
+	}
+	for _, s := range remote {
+		if i, ok := byID[s.ClientSessionID]; ok && s.ClientSessionID != "" {
+			e := &entries[i]
+			e.Source, e.BackendID, e.Credits = sourceBoth, s.ID, s.Credits
+			// Keep the larger counts: an interrupted run may be incomplete on either side
+			e.Tables = max(e.Tables, s.Tables)
+			e.Rows = max(e.Rows, s.Rows)
+			continue
+		}
+		entries = append(entries, sessionEntry{
+			ID:         s.ID,
+			BackendID:  s.ID,
+			Source:     sourceBackend,
+			Database:   s.Database,
+			Tables:     s.Tables,
+			Rows:       s.Rows,
+			StartedAt:  s.StartedAt,
+			DurationMS: s.DurationMS,
+			Status:     s.Status,
+			Credits:    s.Credits,
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].StartedAt.After(entries[j].StartedAt) })
+	return entries
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"seedfast/cli/internal/dsn"
+	"seedfast/cli/internal/history"
+	"seedfast/cli/internal/logging"
+	"seedfast/cli/internal/safety"
+	"seedfast/cli/internal/tracking"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var (
+	undoYes               bool
+	undoWithoutHistory    bool
+	undoIKnowProduction   bool
+	undoProductionPattern string
+)
+
+// undoCmd represents the undo command for removing the rows inserted by a seeding session.
+// Unlike reset, it never touches rows that were not created by Seedfast.
+var undoCmd = &cobra.Command{
+	Use:   "undo [session-id]",
+	Short: "Delete exactly the rows inserted by a seeding session",
+	Long: `The undo command deletes the rows inserted by a 'seedfast seed' session, matched by
+primary key, in reverse foreign-key order inside a single transaction. Data that
+was not created by that session is left untouched.
+
+Only rows inserted by single INSERT statements into tables with a primary key are
+tracked. Run 'seedfast undo' without arguments to list sessions with tracked rows.
+
+The current connection must point at the database the session seeded, on the same
+host and port, as recorded in the local session history. Sessions without a history
+record are refused unless --without-history is given.`,
+	Args: cobra.MaximumNArgs(1),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return listTrackedSessions()
+		}
+		sessionID := args[0]
+
+		cfg, err := loadConfig()
+		if err != nil {
+			pterm.Println("❌ " + err.Error())
+			pterm.Println("   Run 'seedfast config validate' for details.")
+			return err
+		}
+
+		rows, err := tracking.Load(sessionID)
+		if err != nil {
+			pterm.Println("❌ " + err.Error())
+			pterm.Println("   Run 'seedfast undo' to list sessions with tracked rows.")
+			return err
+		}
+
+		rawDSN, err := resolveConnectionDSN("")
+		if err != nil {
+			pterm.Println("❌ " + err.Error())
+			return err
+		}
+		if rawDSN == "" {
+			pterm.Println("⚠️  No database connection configured.")
+			pterm.Println("   Please run 'seedfast connect' to configure your database.")
+			return nil
+		}
+		normalizedDSN, err := dsn.Parse(rawDSN)
+		if err != nil {
+			pterm.Println("❌ Invalid database connection string.")
+			return err
+		}
+		if err := requirePostgres(normalizedDSN, "undo"); err != nil {
+			pterm.Println("❌ " + err.Error())
+			return err
+		}
+		dbName := deriveDBName(normalizedDSN)
+		rec, err := history.Load(sessionID)
+		switch {
+		case errors.Is(err, history.ErrNotFound):
+			if !undoWithoutHistory {
+				pterm.Printf("❌ No history record for session %s, so the seeded database cannot be verified.\n", sessionID)
+				pterm.Println("   Re-run with --without-history if the current connection is the seeded database.")
+				return withExitCode(exitUsage, errors.New("session history record not found"))
+			}
+		case err != nil:
+			pterm.Println("❌ " + err.Error())
+			return err
+		case !history.SameTarget(rec.DSN, logging.Mask(normalizedDSN)):
+			pterm.Printf("❌ Session %s seeded %s, but the current connection targets %s.\n", sessionID, rec.DSN, logging.Mask(normalizedDSN))
+			return errors.New("session target mismatch")
+		}
+
+		pool, err := pgxpool.New(cmd.Context(), normalizedDSN)
+		if err != nil {
+			pterm.Printf("❌ Failed to connect to database\n")
+			pterm.Println(logging.PresentError("", err))
+			return err
+		}
+		defer pool.Close()
+
+		order, err := tracking.UndoOrder(cmd.Context(), pool, rows)
+		if err != nil {
+			pterm.Println(logging.PresentError("❌ Failed to inspect foreign keys", err))
+			return err
+		}
+
+		pterm.Println()
+		pterm.Println(pterm.NewStyle(pterm.FgLightCyan).Sprint("→ Database:   ") + pterm.NewStyle(pterm.FgCyan, pterm.Bold).Sprint(dbName))
+		pterm.Println(pterm.NewStyle(pterm.FgLightCyan).Sprint("→ Session:    ") + sessionID)
+		pterm.Println()
+		pterm.Println("Rows inserted by this session will be deleted, in this order:")
+		var items []pterm.BulletListItem
+		for _, t := range order {
+			items = append(items, pterm.BulletListItem{Level: 0, Text: fmt.Sprintf("%s (%d rows)", t.Table, len(t.Keys))})
+		}
+		_ = pterm.DefaultBulletList.WithItems(items).Render()
+
+		policy, err := safetyPolicy(cfg.Safety, undoProductionPattern)
+		if err != nil {
+			return err
+		}
+		guard := safety.NewGuard(safety.CheckDSN(policy, rawDSN))
+		guard.Update(func(r *safety.Report) { _ = safety.CheckServer(cmd.Context(), policy, pool, r) })
+		if guard.NeedsConfirmation() {
+			if err := confirmProductionTarget(guard, undoIKnowProduction, "seedfast undo "+sessionID); err != nil {
+				return err
+			}
+		}
+
+		if !undoYes {
+			pterm.Print("Type 'yes' to continue: ")
+			ans, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if !strings.EqualFold(strings.TrimSpace(ans), "yes") {
+				pterm.Println("Undo cancelled. No changes were made.")
+				return nil
+			}
+		}
+
+		stop := startInlineSpinner(os.Stdout, "deleting tracked rows", []string{"|", "/", "-", "\\"}, 120*time.Millisecond)
+		deleted, err := tracking.Undo(cmd.Context(), pool, order)
+		stop()
+		if err != nil {
+			pterm.Println(logging.PresentError("❌ Undo failed, no changes were made", err))
+			return err
+		}
+
+		var total int64
+		for _, n := range deleted {
+			total += n
+		}
+		_ = tracking.Remove(sessionID)
+		pterm.Printf("✅ Deleted %d rows from %d tables\n", total, len(order))
+		return nil
+	},
+}
+
+// listTrackedSessions prints all sessions with tracked rows, newest first.
+func listTrackedSessions() error {
+	ids, err := tracking.Sessions()
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		pterm.Println("No sessions with tracked rows found.")
+		return nil
+	}
+	data := pterm.TableData{{"Session", "Database", "Status", "Rows"}}
+	for _, id := range ids {
+		var database, status string
+		if rec, err := history.Load(id); err == nil {
+			database, status = rec.Database, rec.Status
+		}
+		var n int
+		if rows, err := tracking.Load(id); err == nil {
+			for _, t := range rows {
+				n += len(t.Keys)
+			}
+		}
+		data = append(data, []string{id, database, status, fmt.Sprint(n)})
+	}
+	return pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+	undoCmd.Flags().BoolVarP(&undoYes, "yes", "y", false, "Skip the confirmation prompt")
+	undoCmd.Flags().BoolVar(&undoWithoutHistory, "without-history", false, "Undo a session that has no local history record to verify the target database against")
+	undoCmd.Flags().BoolVar(&undoIKnowProduction, "i-know-this-is-production", false, "Allow undoing a session on a database that looks like production (requires typing the database name)")
+	undoCmd.Flags().StringVar(&undoProductionPattern, "production-pattern", "", "Regular expression matched against DSN host and database name to flag production targets (env: SEEDFAST_PRODUCTION_PATTERN)")
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+// Package audit provides an opt-in, append-only audit log of the SQL statements
+// executed against the target database during a seeding session.
+//
+// Entries are written as JSON lines so they can be reviewed with standard tools
+// (jq, grep) or ingested by log pipelines. Every statement and error message is
+// passed through logging.Mask before it reaches disk, and the log file is rotated
+// once it grows beyond a configurable size.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"seedfast/cli/internal/logging"
+)
+
+// Default rotation settings used when a Logger is opened with zero values.
+const (
+	// DefaultMaxSize is the size in bytes after which the log file is rotated.
+	DefaultMaxSize int64 = 10 * 1024 * 1024
+	// DefaultMaxBackups is the number of rotated files kept next to the active log.
+	DefaultMaxBackups = 5
+)
+
+// Entry kinds written to the audit log.
+const (
+	KindSessionStart = "session_start"
+	KindStatement    = "statement"
+	KindSessionEnd   = "session_end"
+)
+
+// Entry is a single line in the audit log.
+// Only a subset of fields is set depending on Kind.
+type Entry struct {
+	Time time.Time `json:"time"`
+	Kind string    `json:"kind"`
+
+	// Session fields
+	SessionID  string `json:"session_id,omitempty"`
+	Database   string `json:"database,omitempty"`
+	DSN        string `json:"dsn,omitempty"`
+	CLIVersion string `json:"cli_version,omitempty"`
+	Status     string `json:"status,omitempty"`
+
+	// Statement fields
+	RequestID    string `json:"request_id,omitempty"`
+	Statement    string `json:"statement,omitempty"`
+	IsWrite      bool   `json:"is_write"`
+	Schema       string `json:"schema,omitempty"`
+	DurationMS   int64  `json:"duration_ms"`
+	RowsAffected int64  `json:"rows_affected"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Options configures rotation for a Logger.
+type Options struct {
+	// MaxSize is the maximum size in bytes of the active file before rotation.
+	MaxSize int64
+	// MaxBackups is the number of rotated files to keep (path.1 ... path.N).
+	MaxBackups int
+}
+
+// Logger appends masked audit entries to a file and rotates it by size.
+// It is safe for concurrent use by multiple seeding workers.
+type Logger struct {
+	path string
+	opts Options
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// Open opens (or creates) the audit log at path with owner-only permissions.
+// Zero-valued options fall back to DefaultMaxSize and DefaultMaxBackups.
+func Open(path string, opts Options) (*Logger, error) {
+	if path == "" {
+		return nil, fmt.Errorf("audit log path is empty")
+	}
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = DefaultMaxSize
+	}
+	if opts.MaxBackups <= 0 {
+		opts.MaxBackups = DefaultMaxBackups
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf("create audit log directory: %w", err)
+		}
+	}
+	l := &Logger{path: path, opts: opts}
+	if err := l.openFile(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// openFile opens the active log file in append mode and records its size.
+func (l *Logger) openFile() error {
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("stat audit log: %w", err)
+	}
+	l.file = f
+	l.size = st.Size()
+	return nil
+}
+
+// Path returns the location of the active audit log file.
+func (l *Logger) Path() string {
+	if l == nil {
+		return ""
+	}
+	return l.path
+}
+
+// Write masks and appends a single entry. A nil Logger is a no-op so callers
+// can pass it around unconditionally when auditing is disabled.
+func (l *Logger) Write(e Entry) error {
+	if l == nil {
+		return nil
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	e.Statement = logging.Mask(e.Statement)
+	e.Error = logging.Mask(e.Error)
+	e.DSN = logging.Mask(e.DSN)
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return fmt.Errorf("audit log is closed")
+	}
+	if l.size > 0 && l.size+int64(len(line)) > l.opts.MaxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	return err
+}
+
+// rotate shifts path.N-1 -> path.N ... path -> path.1 and reopens a fresh file.
+// The caller must hold l.mu.
+func (l *Logger) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	l.file = nil
+	_ = os.Remove(fmt.Sprintf("%s.%d", l.path, l.opts.MaxBackups))
+	for i := l.opts.MaxBackups - 1; i >= 1; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", l.path, i), fmt.Sprintf("%s.%d", l.path, i+1))
+	}
+	if err := os.Rename(l.path, l.path+".1"); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("rotate audit log: %w", err)
+	}
+	return l.openFile()
+}
+
+// Close flushes and closes the active log file.
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
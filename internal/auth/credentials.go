@@ -0,0 +1,212 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"seedfast/cli/internal/keychain"
+)
+
+// Environment variables supplying credentials for headless use.
+const (
+	// EnvAPIKey holds a long-lived API key issued for a service account
+	EnvAPIKey = "SEEDFAST_API_KEY"
+	// EnvAccessToken holds an access token obtained elsewhere (e.g. 'seedfast login' on a workstation)
+	EnvAccessToken = "SEEDFAST_ACCESS_TOKEN"
+	// EnvRefreshToken optionally accompanies EnvAccessToken so expired tokens can be refreshed
+	EnvRefreshToken = "SEEDFAST_REFRESH_TOKEN"
+	// EnvTokenFile points at a token file, like the --token-file flag
+	EnvTokenFile = "SEEDFAST_TOKEN_FILE"
+)
+
+// Source identifies where the credentials of the current process come from.
+type Source string
+
+// Credential sources, in order of precedence.
+const (
+	SourceAPIKey      Source = "api key"
+	SourceEnvironment Source = "environment"
+	SourceTokenFile   Source = "token file"
+	SourceKeychain    Source = "keychain"
+)
+
+// tokenStore is the subset of keychain.Manager used for token handling.
+// External credentials use an in-memory implementation so that refreshed
+// tokens are never written to disk.
+type tokenStore interface {
+	SaveAuthTokens(accessToken, refreshToken string) error
+	LoadAccessToken() (string, error)
+	LoadRefreshToken() (string, error)
+	ClearAuth() error
+}
+
+// memoryStore keeps tokens for the lifetime of the process only.
+type memoryStore struct {
+	mu      sync.RWMutex
+	access  string
+	refresh string
+}
+
+func (m *memoryStore) SaveAuthTokens(accessToken, refreshToken string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if accessToken != "" {
+		m.access = accessToken
+	}
+	if refreshToken != "" {
+		m.refresh = refreshToken
+	}
+	return nil
+}
+
+func (m *memoryStore) LoadAccessToken() (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.access == "" {
+		return "", errors.New("empty access token")
+	}
+	return m.access, nil
+}
+
+func (m *memoryStore) LoadRefreshToken() (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.refresh == "" {
+		return "", errors.New("empty refresh token")
+	}
+	return m.refresh, nil
+}
+
+func (m *memoryStore) ClearAuth() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.access, m.refresh = "", ""
+	return nil
+}
+
+var (
+	credMu    sync.Mutex
+	tokenFile string
+	external  *memoryStore
+	extSource Source
+	resolved  bool
+)
+
+// SetTokenFile makes the process read its credentials from the given file,
+// taking precedence over SEEDFAST_TOKEN_FILE. It must be called before any
+// other auth function.
+func SetTokenFile(path string) {
+	credMu.Lock()
+	defer credMu.Unlock()
+	tokenFile = strings.TrimSpace(path)
+	resolved = false
+}
+
+// tokenFileContents is the JSON form of a token file. A file that is not JSON
+// is read as a bare access token.
+type tokenFileContents struct {
+	APIKey       string `json:"api_key"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// readTokenFile loads credentials from a token file.
+func readTokenFile(path string) (tokenFileContents, error) {
+	var c tokenFileContents
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return c, fmt.Errorf("read token file: %w", err)
+	}
+	raw := strings.TrimSpace(string(b))
+	if strings.HasPrefix(raw, "{") {
+		if err := json.Unmarshal([]byte(raw), &c); err != nil {
+			return c, fmt.Errorf("parse token file %s: %w", path, err)
+		}
+	} else {
+		c.AccessToken = raw
+	}
+	if c.APIKey == "" && c.AccessToken == "" {
+		return c, fmt.Errorf("token file %s contains no token", path)
+	}
+	return c, nil
+}
+
+// externalCredentials resolves credentials supplied outside the keychain, once
+// per process. It returns a nil store when the keychain should be used.
+func externalCredentials() (*memoryStore, Source, error) {
+	credMu.Lock()
+	defer credMu.Unlock()
+	if resolved {
+		return external, extSource, nil
+	}
+
+	var access, refresh string
+	var source Source
+	path := tokenFile
+	if path == "" {
+		path = strings.TrimSpace(os.Getenv(EnvTokenFile))
+	}
+	switch {
+	case strings.TrimSpace(os.Getenv(EnvAPIKey)) != "":
+		access, source = strings.TrimSpace(os.Getenv(EnvAPIKey)), SourceAPIKey
+	case strings.TrimSpace(os.Getenv(EnvAccessToken)) != "":
+		access = strings.TrimSpace(os.Getenv(EnvAccessToken))
+		refresh = strings.TrimSpace(os.Getenv(EnvRefreshToken))
+		source = SourceEnvironment
+	case path != "":
+		c, err := readTokenFile(path)
+		if err != nil {
+			return nil, "", err
+		}
+		if c.APIKey != "" {
+			access, source = c.APIKey, SourceAPIKey
+		} else {
+			access, refresh, source = c.AccessToken, c.RefreshToken, SourceTokenFile
+		}
+	}
+
+	resolved = true
+	if access == "" {
+		external, extSource = nil, ""
+		return nil, "", nil
+	}
+	external = &memoryStore{access: access, refresh: refresh}
+	extSource = source
+	return external, extSource, nil
+}
+
+// CredentialSource reports where the credentials of the current process come from.
+func CredentialSource() (Source, error) {
+	_, source, err := externalCredentials()
+	if err != nil {
+		return "", err
+	}
+	if source == "" {
+		return SourceKeychain, nil
+	}
+	return source, nil
+}
+
+// tokens returns the token store for the current process: in-memory for
+// external credentials, the OS keychain otherwise.
+func tokens() (tokenStore, Source, error) {
+	ext, source, err := externalCredentials()
+	if err != nil {
+		return nil, "", err
+	}
+	if ext != nil {
+		return ext, source, nil
+	}
+	km, err := keychain.GetManager()
+	if err != nil {
+		return nil, "", err
+	}
+	return km, SourceKeychain, nil
+}
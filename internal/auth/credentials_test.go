@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// resetCredentials clears the per-process credential resolution between tests.
+func resetCredentials(t *testing.T) {
+	t.Helper()
+	for _, k := range []string{EnvAPIKey, EnvAccessToken, EnvRefreshToken, EnvTokenFile} {
+		t.Setenv(k, "")
+	}
+	SetTokenFile("")
+}
+
+func TestExternalCredentialPrecedence(t *testing.T) {
+	resetCredentials(t)
+	dir := t.TempDir()
+	file := filepath.Join(dir, "token.json")
+	if err := os.WriteFile(file, []byte(`{"access_token":"file-access","refresh_token":"file-refresh"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	SetTokenFile(file)
+	store, source, err := tokens()
+	if err != nil || source != SourceTokenFile {
+		t.Fatalf("token file: source = %q, err = %v", source, err)
+	}
+	if tok, _ := store.LoadRefreshToken(); tok != "file-refresh" {
+		t.Fatalf("refresh token = %q", tok)
+	}
+
+	t.Setenv(EnvAccessToken, "env-access")
+	SetTokenFile(file)
+	store, source, _ = tokens()
+	if tok, _ := store.LoadAccessToken(); source != SourceEnvironment || tok != "env-access" {
+		t.Fatalf("environment: source = %q, token = %q", source, tok)
+	}
+
+	t.Setenv(EnvAPIKey, "sk_service")
+	SetTokenFile(file)
+	store, source, _ = tokens()
+	if tok, _ := store.LoadAccessToken(); source != SourceAPIKey || tok != "sk_service" {
+		t.Fatalf("api key: source = %q, token = %q", source, tok)
+	}
+
+	st, err := Load()
+	if err != nil || !st.LoggedIn {
+		t.Fatalf("Load() = %+v, %v; want logged in without keychain", st, err)
+	}
+}
+
+func TestTokenFileFormats(t *testing.T) {
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "plain")
+	os.WriteFile(plain, []byte("  bare-token\n"), 0o600)
+	if c, err := readTokenFile(plain); err != nil || c.AccessToken != "bare-token" {
+		t.Fatalf("plain file: %+v, %v", c, err)
+	}
+
+	key := filepath.Join(dir, "key.json")
+	os.WriteFile(key, []byte(`{"api_key":"sk_1"}`), 0o600)
+	if c, err := readTokenFile(key); err != nil || c.APIKey != "sk_1" {
+		t.Fatalf("api key file: %+v, %v", c, err)
+	}
+
+	empty := filepath.Join(dir, "empty.json")
+	os.WriteFile(empty, []byte(`{}`), 0o600)
+	if _, err := readTokenFile(empty); err == nil {
+		t.Fatal("expected error for token file without a token")
+	}
+}
+
+func TestMemoryStoreKeepsRefreshTokenOnPartialSave(t *testing.T) {
+	m := &memoryStore{access: "a1", refresh: "r1"}
+	m.SaveAuthTokens("a2", "")
+	if a, _ := m.LoadAccessToken(); a != "a2" {
+		t.Fatalf("access = %q", a)
+	}
+	if r, _ := m.LoadRefreshToken(); r != "r1" {
+		t.Fatalf("refresh = %q", r)
+	}
+}
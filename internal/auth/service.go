@@ -3,12 +3,14 @@
 
 // Package auth provides authentication services for the Seedfast CLI.
 // It manages the device authorization flow, token refresh, and session validation.
-// Authentication state is persisted in local XDG config directory, while secrets
-// (tokens, DSN) are stored in the OS keychain for security.
+// Authentication state and secrets (tokens, DSN) are stored in the OS keychain.
+// For headless use, credentials may instead come from the environment, a token
+// file or a service-account API key; these are kept in memory only.
 package auth
 
 import (
 	"context"
+	"errors"
 
 	"seedfast/cli/internal/backend"
 	"seedfast/cli/internal/keychain"
@@ -28,18 +30,22 @@ func NewService(baseURL string, endpoints manifest.HTTPEndpoints) *Service {
 
 // StartLogin begins the device-link login flow.
 func (s *Service) StartLogin(ctx context.Context) (authURL string, deviceID string, pollIntervalSeconds int, err error) {
-	return s.be.BeginDeviceLink(ctx)
+	link, err := s.be.BeginDeviceLink(ctx)
+	if err != nil {
+		return "", "", 0, err
+	}
+	return link.Link, link.DeviceID, link.PollInterval, nil
 }
 
 // PollLogin attempts to complete login for the given deviceID.
 // When tokens are issued, they are saved to secure storage and local state is updated.
 // Returns (account, true, nil) on success; (_, false, nil) if still pending.
 func (s *Service) PollLogin(ctx context.Context, deviceID string) (string, bool, error) {
-	access, refresh, err := s.be.PollDeviceLink(ctx, deviceID)
+	issued, err := s.be.PollDeviceLink(ctx, deviceID)
 	if err != nil {
 		return "", false, err
 	}
-	if access == "" {
+	if issued == nil {
 		return "", false, nil
 	}
 
@@ -49,11 +55,11 @@ func (s *Service) PollLogin(ctx context.Context, deviceID string) (string, bool,
 		return "", false, err
 	}
 
-	if err := km.SaveAuthTokens(access, refresh); err != nil {
+	if err := km.SaveAuthTokens(issued.AccessToken, issued.RefreshToken); err != nil {
 		return "", false, err
 	}
 	userID := ""
-	if uid, err := s.be.CheckDevice(ctx, access); err == nil && uid != "" {
+	if uid, err := s.be.CheckDevice(ctx, issued.AccessToken); err == nil && uid != "" {
 		userID = uid
 	}
 	// Persist minimal state; we store user_id in Account for display
@@ -70,48 +76,27 @@ func (s *Service) PollLogin(ctx context.Context, deviceID string) (string, bool,
 // If token is expired, attempts to refresh. If refresh fails, logs out the user.
 // Returns email if available, otherwise user_id, otherwise "user".
 func (s *Service) WhoAmI(ctx context.Context) (string, bool, error) {
-	// Try to get keychain manager - if it fails, user is not logged in
-	km, err := keychain.GetManager()
+	// Resolve the token store - if it fails, user is not logged in
+	km, _, err := tokens()
 	if err != nil {
-		return "", false, nil // Keychain unavailable = not logged in
+		return "", false, nil // No credentials available = not logged in
 	}
 
 	token, err := km.LoadAccessToken()
 	if err == nil && token != "" {
 		// Try new /api/cli/me endpoint first (supports caching)
-		userData, meErr := s.be.GetMe(ctx, token)
-		if meErr == nil && userData != nil {
-			// Extract user identifier - prefer email, then user_id, then id
-			if email, ok := userData["email"].(string); ok && email != "" {
-				return email, true, nil
-			}
-			if uid, ok := userData["user_id"].(string); ok && uid != "" {
-				return uid, true, nil
-			}
-			if uid, ok := userData["id"].(string); ok && uid != "" {
-				return uid, true, nil
-			}
-			// If userData exists but no identifier, still consider it valid
-			return "user", true, nil
+		user, meErr := s.be.GetMe(ctx, token)
+		if meErr == nil {
+			return accountName(user), true, nil
 		}
 
 		// If we got an unauthorized error, try to refresh the token
-		if meErr != nil && meErr.Error() == "unauthorized" {
+		if errors.Is(meErr, backend.ErrUnauthorized) {
 			if refreshed, _ := s.RefreshAccessToken(ctx); refreshed {
 				// Retry with new token
 				if newToken, err := km.LoadAccessToken(); err == nil && newToken != "" {
-					if userData, err := s.be.GetMe(ctx, newToken); err == nil && userData != nil {
-						// Prefer email, then user_id, then id
-						if email, ok := userData["email"].(string); ok && email != "" {
-							return email, true, nil
-						}
-						if uid, ok := userData["user_id"].(string); ok && uid != "" {
-							return uid, true, nil
-						}
-						if uid, ok := userData["id"].(string); ok && uid != "" {
-							return uid, true, nil
-						}
-						return "user", true, nil
+					if user, err := s.be.GetMe(ctx, newToken); err == nil {
+						return accountName(user), true, nil
 					}
 				}
 			} else {
@@ -138,11 +123,16 @@ func (s *Service) WhoAmI(ctx context.Context) (string, bool, error) {
 }
 
 // Logout performs remote logout (best-effort) and clears local credentials/state.
+// Externally supplied credentials are only dropped from memory; they are never
+// invalidated on the backend since other jobs may share them.
 func (s *Service) Logout(ctx context.Context) error {
-	km, err := keychain.GetManager()
+	km, source, err := tokens()
 	if err != nil {
 		return err
 	}
+	if source != SourceKeychain {
+		return km.ClearAuth()
+	}
 	if token, err := km.LoadAccessToken(); err == nil && token != "" {
 		_ = s.be.Logout(ctx, token)
 	}
@@ -157,10 +147,13 @@ func (s *Service) Logout(ctx context.Context) error {
 
 // ResetLocalAuth clears only local credentials/state (no remote calls).
 func (s *Service) ResetLocalAuth() error {
-	km, err := keychain.GetManager()
+	km, source, err := tokens()
 	if err != nil {
 		return err
 	}
+	if source != SourceKeychain {
+		return km.ClearAuth()
+	}
 	if err := km.ClearAuth(); err != nil {
 		return err
 	}
@@ -171,10 +164,10 @@ func (s *Service) ResetLocalAuth() error {
 }
 
 // RefreshAccessToken attempts to refresh the access token using the stored refresh token.
-// If successful, updates the stored tokens in the keychain.
+// If successful, updates the stored tokens (in memory for external credentials).
 // Returns true if refresh was successful, false otherwise.
 func (s *Service) RefreshAccessToken(ctx context.Context) (bool, error) {
-	km, err := keychain.GetManager()
+	km, _, err := tokens()
 	if err != nil {
 		return false, err
 	}
@@ -186,19 +179,19 @@ func (s *Service) RefreshAccessToken(ctx context.Context) (bool, error) {
 	}
 
 	// Call backend to refresh
-	newAccessToken, newRefreshToken, err := s.be.RefreshToken(ctx, refreshToken)
+	refreshed, err := s.be.RefreshToken(ctx, refreshToken)
 	if err != nil {
 		return false, err
 	}
 
 	// Save new access token (always returned)
-	if err := km.SaveAuthTokens(newAccessToken, ""); err != nil {
+	if err := km.SaveAuthTokens(refreshed.AccessToken, ""); err != nil {
 		return false, err
 	}
 
 	// If a new refresh token was provided, update it too
-	if newRefreshToken != "" {
-		if err := km.SaveAuthTokens("", newRefreshToken); err != nil {
+	if refreshed.RefreshToken != "" {
+		if err := km.SaveAuthTokens("", refreshed.RefreshToken); err != nil {
 			return false, err
 		}
 	}
@@ -210,7 +203,7 @@ func (s *Service) RefreshAccessToken(ctx context.Context) (bool, error) {
 // Just returns the stored token without validation or refresh.
 // For automatic token refresh, use GetValidAccessToken instead.
 func (s *Service) GetAccessToken(ctx context.Context) (string, error) {
-	km, err := keychain.GetManager()
+	km, _, err := tokens()
 	if err != nil {
 		return "", err
 	}
@@ -224,7 +217,7 @@ func (s *Service) GetAccessToken(ctx context.Context) (string, error) {
 // GetValidAccessToken retrieves a valid access token, automatically refreshing if needed.
 // If both access and refresh tokens are expired, clears auth state and returns an error.
 func (s *Service) GetValidAccessToken(ctx context.Context) (string, error) {
-	km, err := keychain.GetManager()
+	km, _, err := tokens()
 	if err != nil {
 		return "", err
 	}
@@ -237,7 +230,7 @@ func (s *Service) GetValidAccessToken(ctx context.Context) (string, error) {
 	if _, err := s.be.GetMe(ctx, token); err == nil {
 		// Token is valid
 		return token, nil
-	} else if err.Error() == "unauthorized" {
+	} else if errors.Is(err, backend.ErrUnauthorized) {
 		// Token expired, try to refresh
 		if refreshed, _ := s.RefreshAccessToken(ctx); refreshed {
 			// Get the new token
@@ -257,7 +250,7 @@ func (s *Service) GetValidAccessToken(ctx context.Context) (string, error) {
 // WarmCache pre-fetches user data from /api/cli/me to populate the cache.
 // This is typically called right after successful login to enable offline whoami.
 func (s *Service) WarmCache(ctx context.Context) error {
-	km, err := keychain.GetManager()
+	km, _, err := tokens()
 	if err != nil {
 		return err
 	}
@@ -270,10 +263,9 @@ func (s *Service) WarmCache(ctx context.Context) error {
 	return nil
 }
 
-// GetUserData retrieves full user data from the /api/cli/me endpoint.
-// Returns a map with user fields like email, user_id, etc.
-func (s *Service) GetUserData(ctx context.Context) (map[string]any, error) {
-	km, err := keychain.GetManager()
+// GetUserData retrieves the user from the /api/cli/me endpoint.
+func (s *Service) GetUserData(ctx context.Context) (*backend.User, error) {
+	km, _, err := tokens()
 	if err != nil {
 		return nil, err
 	}
@@ -283,3 +275,30 @@ func (s *Service) GetUserData(ctx context.Context) (map[string]any, error) {
 	}
 	return s.be.GetMe(ctx, token)
 }
+
+// GetUsage retrieves the plan and credit usage of the current period.
+func (s *Service) GetUsage(ctx context.Context) (*backend.Usage, error) {
+	token, err := s.GetValidAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.be.GetUsage(ctx, token)
+}
+
+// ListSessions retrieves up to limit recent seeding sessions from the backend.
+func (s *Service) ListSessions(ctx context.Context, limit int) ([]backend.Session, error) {
+	token, err := s.GetValidAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.be.ListSessions(ctx, token, limit)
+}
+
+// accountName returns the identifier to show for a user, or "user" when the
+// backend returned none.
+func accountName(u *backend.User) string {
+	if id := u.Identifier(); id != "" {
+		return id
+	}
+	return "user"
+}
@@ -26,6 +26,8 @@ type State struct {
 }
 
 // Load reads the auth state from the keychain. Missing state yields zero value.
+// When credentials are supplied externally (API key, environment or token file)
+// the user is reported as logged in without touching the keychain.
 func Load() (State, error) {
 	verbose := isVerbose()
 	if verbose {
@@ -33,6 +35,18 @@ func Load() (State, error) {
 	}
 
 	var s State
+	// Externally supplied credentials need no keychain state
+	_, source, err := externalCredentials()
+	if err != nil {
+		return s, err
+	}
+	switch source {
+	case SourceAPIKey:
+		return State{LoggedIn: true, Account: "service account"}, nil
+	case SourceEnvironment, SourceTokenFile:
+		return State{LoggedIn: true, Account: "user"}, nil
+	}
+
 	km, err := keychain.GetManager()
 	if err != nil {
 		if verbose {
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// TokenStatus describes the stored credentials without contacting the backend.
+type TokenStatus struct {
+	// Source is where the credentials come from
+	Source Source
+	// HasAccessToken and HasRefreshToken report which tokens are present
+	HasAccessToken  bool
+	HasRefreshToken bool
+	// ExpiresAt is the expiry of the access token when it is a JWT, zero otherwise
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the access token is known to have expired at t.
+func (s TokenStatus) Expired(t time.Time) bool {
+	return !s.ExpiresAt.IsZero() && !t.Before(s.ExpiresAt)
+}
+
+// Tokens inspects the stored tokens of the current process.
+func Tokens() (TokenStatus, error) {
+	store, source, err := tokens()
+	if err != nil {
+		return TokenStatus{}, err
+	}
+	st := TokenStatus{Source: source}
+	if access, err := store.LoadAccessToken(); err == nil && access != "" {
+		st.HasAccessToken = true
+		st.ExpiresAt = tokenExpiry(access)
+	}
+	if refresh, err := store.LoadRefreshToken(); err == nil && refresh != "" {
+		st.HasRefreshToken = true
+	}
+	return st, nil
+}
+
+// tokenExpiry reads the exp claim of a JWT without verifying its signature;
+// the backend remains the authority on validity. It returns the zero time for
+// opaque tokens and API keys.
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}
+	}
+	exp, err := claims.Exp.Float64()
+	if err != nil || exp <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(exp), 0)
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package auth
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestTokenStatus(t *testing.T) {
+	resetCredentials(t)
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"u1","exp":1767225600}`))
+	t.Setenv(EnvAccessToken, "eyJhbGciOiJIUzI1NiJ9."+payload+".c2ln")
+
+	st, err := Tokens()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Unix(1767225600, 0)
+	if st.Source != SourceEnvironment || !st.HasAccessToken || st.HasRefreshToken || !st.ExpiresAt.Equal(want) {
+		t.Fatalf("Tokens() = %+v", st)
+	}
+	if st.Expired(want.Add(-time.Minute)) || !st.Expired(want) {
+		t.Fatal("Expired() boundary")
+	}
+
+	if exp := tokenExpiry("sk_live_opaque"); !exp.IsZero() {
+		t.Fatalf("opaque token expiry = %v", exp)
+	}
+}
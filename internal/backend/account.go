@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package backend
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+var usageSchemas = []schema[*Usage]{
+	direct[Usage](),
+	enveloped[Usage]("data"),
+}
+
+var sessionListSchemas = []schema[*SessionList]{
+	direct[SessionList](),
+	enveloped[SessionList]("data"),
+}
+
+// GetUsage calls GET /api/cli/usage with Authorization header.
+func (h *HTTP) GetUsage(ctx context.Context, accessToken string) (*Usage, error) {
+	if h.endpoints.AccountUsage == "" {
+		return nil, ErrNotSupported
+	}
+	resp, err := h.getAuthorized(ctx, h.baseURL+h.endpoints.AccountUsage, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return decodeResponse("usage", resp, nil, usageSchemas...)
+	case http.StatusUnauthorized:
+		return nil, ErrUnauthorized
+	case http.StatusNotFound:
+		return nil, ErrNotSupported
+	}
+	return nil, statusError("usage", resp)
+}
+
+// ListSessions calls GET /api/cli/sessions?limit=<n> with Authorization header.
+func (h *HTTP) ListSessions(ctx context.Context, accessToken string, limit int) ([]Session, error) {
+	if h.endpoints.AccountSessions == "" {
+		return nil, ErrNotSupported
+	}
+	u := h.baseURL + h.endpoints.AccountSessions
+	if limit > 0 {
+		u += "?" + url.Values{"limit": {strconv.Itoa(limit)}}.Encode()
+	}
+	resp, err := h.getAuthorized(ctx, u, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		list, err := decodeResponse("sessions", resp, nil, sessionListSchemas...)
+		if err != nil {
+			return nil, err
+		}
+		return list.Sessions, nil
+	case http.StatusUnauthorized:
+		return nil, ErrUnauthorized
+	case http.StatusNotFound:
+		return nil, ErrNotSupported
+	}
+	return nil, statusError("sessions", resp)
+}
+
+// getAuthorized sends a GET request with the bearer token.
+func (h *HTTP) getAuthorized(ctx context.Context, target, accessToken string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	h.setStandardHeaders(req)
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	return h.client.Do(req)
+}
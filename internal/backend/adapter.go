@@ -13,17 +13,26 @@ import "context"
 type API interface {
 	GetVersion(ctx context.Context) (string, error)
 	GetCLIVersion(ctx context.Context) (string, error)
-	BeginDeviceLink(ctx context.Context) (authURL string, userCode string, pollIntervalSeconds int, err error)
-	PollDeviceLink(ctx context.Context, userCode string) (accessToken string, refreshToken string, err error)
+	// BeginDeviceLink starts the device authorization flow.
+	BeginDeviceLink(ctx context.Context) (*DeviceLink, error)
+	// PollDeviceLink returns the issued tokens once the device is authorized,
+	// or nil while authorization is pending.
+	PollDeviceLink(ctx context.Context, deviceID string) (*Tokens, error)
 	// CheckDevice validates the current access token with the backend and
 	// returns the associated user identifier when available.
 	CheckDevice(ctx context.Context, accessToken string) (userID string, err error)
 	// Logout invalidates the current access token on the backend.
 	Logout(ctx context.Context, accessToken string) error
 	// GetMe retrieves the current user's information from the backend.
-	// Returns user data as a map containing fields like user_id, email, etc.
-	GetMe(ctx context.Context, accessToken string) (map[string]any, error)
+	// Returns ErrUnauthorized when the access token is rejected.
+	GetMe(ctx context.Context, accessToken string) (*User, error)
 	// RefreshToken exchanges a refresh token for a new access token.
-	// Returns new access token and optionally a new refresh token.
-	RefreshToken(ctx context.Context, refreshToken string) (newAccessToken string, newRefreshToken string, err error)
+	// The returned refresh token is empty when the backend did not rotate it.
+	RefreshToken(ctx context.Context, refreshToken string) (*Tokens, error)
+	// GetUsage returns the plan and credit usage of the current period.
+	// Returns ErrNotSupported when the backend does not report usage.
+	GetUsage(ctx context.Context, accessToken string) (*Usage, error)
+	// ListSessions returns up to limit recent seeding sessions, newest first.
+	// Returns ErrNotSupported when the backend does not keep session history.
+	ListSessions(ctx context.Context, accessToken string, limit int) ([]Session, error)
 }
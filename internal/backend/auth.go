@@ -4,80 +4,75 @@
 package backend
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 )
 
+// defaultPollInterval is used when the backend does not suggest one.
+const defaultPollInterval = 3
+
+// legacyDeviceLink is the get-link response of earlier API revisions, which
+// named the device code in several ways.
+type legacyDeviceLink struct {
+	Link            string `json:"link"`
+	DeviceIDCamel   string `json:"deviceId"`
+	Code            string `json:"code"`
+	UserCode        string `json:"user_code"`
+	UserCodeCamel   string `json:"userCode"`
+	DeviceCode      string `json:"device_code"`
+	DeviceCodeCamel string `json:"deviceCode"`
+}
+
+var deviceLinkSchemas = []schema[*DeviceLink]{
+	direct[DeviceLink](),
+	converted(func(l *legacyDeviceLink) *DeviceLink {
+		d := &DeviceLink{Link: l.Link}
+		for _, v := range []string{l.DeviceIDCamel, l.Code, l.UserCode, l.UserCodeCamel, l.DeviceCode, l.DeviceCodeCamel} {
+			if v = strings.TrimSpace(v); v != "" {
+				d.DeviceID = v
+				break
+			}
+		}
+		return d
+	}),
+}
+
 // BeginDeviceLink fetches a magic link from /api/cli/get-link.
 // It initiates the device authorization flow by requesting a link and device code from the backend.
-// Returns the magic link URL, device ID/code, polling interval in seconds, and any error.
-func (h *HTTP) BeginDeviceLink(ctx context.Context) (string, string, int, error) {
+// When the response carries no device code, it is taken from the link URL.
+func (h *HTTP) BeginDeviceLink(ctx context.Context) (*DeviceLink, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.baseURL+h.endpoints.GetLink, nil)
 	if err != nil {
-		return "", "", 0, err
+		return nil, err
 	}
 	h.setStandardHeaders(req)
 
 	resp, err := h.client.Do(req)
 	if err != nil {
-		return "", "", 0, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(resp.Body)
-		return "", "", 0, fmt.Errorf("get-link failed: %s", strings.TrimSpace(string(b)))
-	}
-
-	// Be liberal in what we accept: decode into a map first
-	var raw map[string]any
-	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
-		return "", "", 0, err
+		return nil, statusError("get-link", resp)
 	}
 
-	link := extractLink(raw)
-	if link == "" {
-		return "", "", 0, errors.New("empty magic link")
-	}
-
-	deviceID := extractDeviceID(raw, link)
-	return link, deviceID, 3, nil
-}
-
-// extractLink extracts the magic link from the response payload.
-func extractLink(raw map[string]any) string {
-	if v, ok := raw["link"].(string); ok {
-		return strings.TrimSpace(v)
-	}
-	return ""
-}
-
-// extractDeviceID extracts the device ID/code from various possible fields in the response.
-// It tries multiple common field names and formats to be resilient to backend changes.
-func extractDeviceID(raw map[string]any, link string) string {
-	// Try common field names
-	candidates := []string{
-		"device_id", "deviceId", "code", "user_code", "userCode", "device_code", "deviceCode",
+	link, err := decodeResponse("get-link", resp, (*DeviceLink).validate, deviceLinkSchemas...)
+	if err != nil {
+		return nil, err
 	}
-
-	for _, key := range candidates {
-		if v, ok := raw[key].(string); ok && strings.TrimSpace(v) != "" {
-			return strings.TrimSpace(v)
-		}
+	link.Link = strings.TrimSpace(link.Link)
+	if link.DeviceID == "" {
+		link.DeviceID = extractDeviceIDFromURL(link.Link)
 	}
-
-	// Fallback: parse from the link URL
-	if deviceID := extractDeviceIDFromURL(link); deviceID != "" {
-		return deviceID
+	if link.PollInterval <= 0 {
+		link.PollInterval = defaultPollInterval
 	}
-
-	return ""
+	return link, nil
 }
 
 // extractDeviceIDFromURL attempts to extract a device ID from query parameters or path segments.
@@ -107,124 +102,49 @@ func extractDeviceIDFromURL(link string) string {
 }
 
 // PollDeviceLink posts to /api/cli/get-token with { device_id }.
-// Returns empty tokens when not ready yet (pending authorization).
+// Returns nil tokens while the authorization is pending, including when the
+// request fails transiently, so that the caller keeps polling.
 // Returns access token and refresh token when the device has been authorized.
-func (h *HTTP) PollDeviceLink(ctx context.Context, deviceID string) (string, string, error) {
-	// POST JSON strictly as per API: { "device_id": "<uuid>" }
-	jsonBody := map[string]string{
-		"device_id": deviceID,
-	}
-	if access, refresh, ok := h.tryVerifyPostJSON(ctx, jsonBody, parseTokensFromBody); ok {
-		return access, refresh, nil
-	}
-
-	// Pending (not yet authorized)
-	return "", "", nil
-}
-
-// parseTokensFromBody extracts access and refresh tokens from the response body.
-// It supports both JSON responses (with nested structures) and plain text responses.
-func parseTokensFromBody(r io.Reader, contentType string) (string, string) {
-	lowerCT := strings.ToLower(contentType)
-	if strings.Contains(lowerCT, "application/json") || strings.Contains(lowerCT, "+json") || contentType == "" {
-		var anyBody any
-		dec := json.NewDecoder(r)
-		if err := dec.Decode(&anyBody); err == nil {
-			var access, refresh string
-			walkJSON(anyBody, &access, &refresh)
-			return access, refresh
-		}
-	}
-
-	// Fallback to plain text
-	b, _ := io.ReadAll(r)
-	token := strings.TrimSpace(string(b))
-	if token != "" {
-		return token, ""
-	}
-	return "", ""
-}
-
-// walkJSON recursively searches a JSON structure for access and refresh tokens.
-// It handles various common field naming conventions.
-func walkJSON(node any, access *string, refresh *string) {
-	if *access != "" && *refresh != "" {
-		return
-	}
-
-	switch v := node.(type) {
-	case map[string]any:
-		for k, vv := range v {
-			lk := strings.ToLower(strings.ReplaceAll(k, "_", ""))
-			if s, ok := vv.(string); ok {
-				val := strings.TrimSpace(s)
-				if *access == "" {
-					if lk == "accesstoken" || lk == "access" || lk == "token" || lk == "bearer" {
-						*access = val
-					} else if lk == "authorization" {
-						if t := parseBearerToken(val); t != "" {
-							*access = t
-						}
-					}
-				}
-				if *refresh == "" {
-					if lk == "refreshtoken" || lk == "refresh" {
-						*refresh = val
-					}
-				}
-			}
-			if *access == "" || *refresh == "" {
-				walkJSON(vv, access, refresh)
-			}
-		}
-	case []any:
-		for _, e := range v {
-			if *access == "" || *refresh == "" {
-				walkJSON(e, access, refresh)
-			}
-		}
-	}
-}
-
-// tryVerifyPostJSON posts JSON to get-token and returns tokens when successful.
-// It returns (access, refresh, true) on success, or ("", "", false) if pending or failed.
-func (h *HTTP) tryVerifyPostJSON(ctx context.Context, body map[string]string, parse func(io.Reader, string) (string, string)) (string, string, bool) {
-	b, err := json.Marshal(body)
+func (h *HTTP) PollDeviceLink(ctx context.Context, deviceID string) (*Tokens, error) {
+	b, err := json.Marshal(TokenRequest{DeviceID: deviceID})
 	if err != nil {
-		return "", "", false
+		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.baseURL+h.endpoints.GetToken, strings.NewReader(string(b)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.baseURL+h.endpoints.GetToken, bytes.NewReader(b))
 	if err != nil {
-		return "", "", false
+		return nil, err
 	}
 	h.setStandardHeaders(req)
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := h.client.Do(req)
 	if err != nil {
-		return "", "", false
+		return nil, nil
 	}
 	defer resp.Body.Close()
 
-	switch resp.StatusCode {
-	case http.StatusOK, http.StatusCreated:
-		// Check for token in headers first
-		if token := findBearerTokenInHeaders(resp.Header); token != "" {
-			return token, "", true
-		}
-		// Parse from body
-		access, refresh := parse(resp.Body, resp.Header.Get("Content-Type"))
-		if access != "" || refresh != "" {
-			return access, refresh, true
-		}
-		return "", "", false
-	case http.StatusNoContent, http.StatusAccepted, http.StatusNotFound, http.StatusBadRequest, http.StatusMethodNotAllowed, http.StatusUnsupportedMediaType:
-		// Pending or invalid request
-		return "", "", false
-	default:
-		return "", "", false
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		// 202/204 while pending; 400/404 until the device code is known
+		return nil, nil
+	}
+	tokens, err := decodeResponse("get-token", resp, nil, tokenSchemas...)
+	if err != nil {
+		return nil, err
+	}
+	if tokens.AccessToken == "" {
+		// Earlier revisions returned the token in the Authorization header
+		tokens.AccessToken = parseBearerToken(resp.Header.Get("Authorization"))
+	}
+	if tokens.AccessToken == "" {
+		return nil, nil
 	}
+	return tokens, nil
+}
+
+var deviceConfirmationSchemas = []schema[*DeviceConfirmation]{
+	direct[DeviceConfirmation](),
+	enveloped[DeviceConfirmation]("data"),
 }
 
 // CheckDevice calls POST /api/cli/check-device with Authorization: Bearer <token>.
@@ -245,21 +165,17 @@ func (h *HTTP) CheckDevice(ctx context.Context, accessToken string) (string, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusOK {
-		var out map[string]any
-		if err := json.NewDecoder(resp.Body).Decode(&out); err == nil {
-			if v, ok := out["user_id"].(string); ok && v != "" {
-				return v, nil
-			}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		out, err := decodeResponse("check-device", resp, (*DeviceConfirmation).validate, deviceConfirmationSchemas...)
+		if err != nil {
+			return "", err
 		}
-		return "", errors.New("unexpected response")
+		return out.UserID, nil
+	case http.StatusUnauthorized:
+		return "", ErrUnauthorized
 	}
-	if resp.StatusCode == http.StatusUnauthorized {
-		return "", errors.New("unauthorized")
-	}
-
-	b, _ := io.ReadAll(resp.Body)
-	return "", fmt.Errorf("check-device failed: %d %s", resp.StatusCode, strings.TrimSpace(string(b)))
+	return "", statusError("check-device", resp)
 }
 
 // Logout calls POST /api/cli/logout with Authorization header.
@@ -287,7 +203,5 @@ func (h *HTTP) Logout(ctx context.Context, accessToken string) error {
 	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent {
 		return nil
 	}
-
-	b, _ := io.ReadAll(resp.Body)
-	return fmt.Errorf("logout failed: %d %s", resp.StatusCode, strings.TrimSpace(string(b)))
+	return statusError("logout", resp)
 }
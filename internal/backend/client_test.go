@@ -0,0 +1,278 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"seedfast/cli/internal/manifest"
+)
+
+// fixture is a recorded backend exchange from testdata/fixtures.
+type fixture struct {
+	Request struct {
+		Method        string          `json:"method"`
+		Path          string          `json:"path"`
+		Authorization string          `json:"authorization"`
+		Body          json.RawMessage `json:"body"`
+	} `json:"request"`
+	Response struct {
+		Status  int               `json:"status"`
+		Headers map[string]string `json:"headers"`
+		Body    json.RawMessage   `json:"body"`
+	} `json:"response"`
+}
+
+var testEndpoints = manifest.HTTPEndpoints{
+	ConfirmDevice: "/api/cli/check-device",
+	GetToken:      "/api/cli/get-token",
+	GetLink:       "/api/cli/get-link",
+	RefreshToken:  "/api/cli/refresh-token",
+	Logout:        "/api/cli/logout",
+	Me:            "/api/cli/me",
+	Version:       "/api/version",
+	CLIVersion:    "/api/cli/version",
+	// Optional endpoints
+	AccountUsage:    "/api/cli/usage",
+	AccountSessions: "/api/cli/sessions",
+}
+
+// replay starts a server that answers with the named fixture after checking
+// that the client sent the recorded request.
+func replay(t *testing.T, name string) *HTTP {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "fixtures", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatalf("fixture %s: %v", name, err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != f.Request.Method || r.URL.Path != f.Request.Path {
+			t.Errorf("request %s %s, recorded %s %s", r.Method, r.URL.Path, f.Request.Method, f.Request.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != f.Request.Authorization {
+			t.Errorf("Authorization %q, recorded %q", got, f.Request.Authorization)
+		}
+		if len(f.Request.Body) > 0 {
+			body, _ := io.ReadAll(r.Body)
+			if !jsonEqual(body, f.Request.Body) {
+				t.Errorf("request body %s, recorded %s", body, f.Request.Body)
+			}
+		}
+		for k, v := range f.Response.Headers {
+			w.Header().Set(k, v)
+		}
+		if len(f.Response.Body) > 0 {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.WriteHeader(f.Response.Status)
+		w.Write(f.Response.Body)
+	}))
+	t.Cleanup(srv.Close)
+	return newHTTP(srv.URL, testEndpoints)
+}
+
+func jsonEqual(a, b []byte) bool {
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func TestBeginDeviceLink(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    *DeviceLink
+	}{
+		{"get_link", &DeviceLink{Link: "https://seedfa.st/cli/authorize?device_id=dev-123", DeviceID: "dev-123", PollInterval: 5}},
+		{"get_link_v0", &DeviceLink{Link: "https://seedfa.st/cli/authorize/dev-legacy", DeviceID: "dev-legacy", PollInterval: 3}},
+		{"get_link_code_in_url", &DeviceLink{Link: "https://seedfa.st/cli/authorize?code=dev-url", DeviceID: "dev-url", PollInterval: 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			got, err := replay(t, tt.fixture).BeginDeviceLink(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != *tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	var decodeErr *DecodeError
+	if _, err := replay(t, "get_link_empty").BeginDeviceLink(context.Background()); !errors.As(err, &decodeErr) {
+		t.Errorf("get_link_empty: err = %v, want DecodeError", err)
+	}
+}
+
+func TestPollDeviceLink(t *testing.T) {
+	issued := &Tokens{AccessToken: "at-1", RefreshToken: "rt-1"}
+	tests := []struct {
+		fixture string
+		want    *Tokens
+	}{
+		{"get_token", &Tokens{AccessToken: "at-1", RefreshToken: "rt-1", ExpiresIn: 900, TokenType: "Bearer"}},
+		{"get_token_data", issued},
+		{"get_token_v0", issued},
+		{"get_token_header", &Tokens{AccessToken: "at-1"}},
+		{"get_token_pending", nil},
+		{"get_token_pending_ok", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			got, err := replay(t, tt.fixture).PollDeviceLink(context.Background(), "dev-123")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	got, err := replay(t, "refresh_token").RefreshToken(context.Background(), "rt-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AccessToken != "at-2" || got.RefreshToken != "rt-2" || got.ExpiresIn != 900 {
+		t.Errorf("refresh_token: got %+v", got)
+	}
+
+	got, err = replay(t, "refresh_token_not_rotated").RefreshToken(context.Background(), "rt-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AccessToken != "at-2" || got.RefreshToken != "" {
+		t.Errorf("refresh_token_not_rotated: got %+v", got)
+	}
+
+	var decodeErr *DecodeError
+	if _, err := replay(t, "refresh_token_missing").RefreshToken(context.Background(), "rt-1"); !errors.As(err, &decodeErr) {
+		t.Errorf("refresh_token_missing: err = %v, want DecodeError", err)
+	}
+	if _, err := replay(t, "refresh_token_expired").RefreshToken(context.Background(), "rt-1"); err == nil {
+		t.Error("refresh_token_expired: expected an error")
+	}
+}
+
+func TestGetMe(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    User
+	}{
+		{"me", User{ID: "6f1c", UserID: "usr_1", Email: "dev@example.com", Name: "Dev", Plan: "team"}},
+		{"me_envelope", User{UserID: "usr_1"}},
+		{"me_v0", User{ID: "6f1c", UserID: "usr_1"}},
+		{"me_newer", User{Email: "dev@example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			got, err := replay(t, tt.fixture).GetMe(context.Background(), "at-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := replay(t, "me_unauthorized").GetMe(context.Background(), "at-1"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("me_unauthorized: err = %v, want ErrUnauthorized", err)
+	}
+	var decodeErr *DecodeError
+	if _, err := replay(t, "me_malformed").GetMe(context.Background(), "at-1"); !errors.As(err, &decodeErr) {
+		t.Errorf("me_malformed: err = %v, want DecodeError", err)
+	}
+}
+
+func TestGetMeServesCacheWhenOffline(t *testing.T) {
+	h := replay(t, "me")
+	if _, err := h.GetMe(context.Background(), "at-1"); err != nil {
+		t.Fatal(err)
+	}
+	// Point the client at a closed server: the cached user is returned
+	h.baseURL = "http://127.0.0.1:1"
+	h.meCacheTime = h.meCacheTime.Add(-time.Hour)
+	got, err := h.GetMe(context.Background(), "at-1")
+	if err != nil || got.Identifier() != "dev@example.com" {
+		t.Errorf("GetMe offline = %+v, %v; want cached user", got, err)
+	}
+}
+
+func TestCheckDevice(t *testing.T) {
+	uid, err := replay(t, "check_device").CheckDevice(context.Background(), "at-1")
+	if err != nil || uid != "usr_1" {
+		t.Errorf("check_device = %q, %v", uid, err)
+	}
+	if _, err := replay(t, "check_device_unexpected").CheckDevice(context.Background(), "at-1"); err == nil {
+		t.Error("check_device_unexpected: expected an error")
+	}
+}
+
+func TestLogout(t *testing.T) {
+	if err := replay(t, "logout").Logout(context.Background(), "at-1"); err != nil {
+		t.Errorf("logout: %v", err)
+	}
+	var statusErr *StatusError
+	if err := replay(t, "logout_failed").Logout(context.Background(), "at-1"); !errors.As(err, &statusErr) || statusErr.Code != http.StatusInternalServerError {
+		t.Errorf("logout_failed: err = %v, want StatusError 500", err)
+	}
+}
+
+func TestVersions(t *testing.T) {
+	if v, err := replay(t, "version").GetVersion(context.Background()); err != nil || v != "2.3.0" {
+		t.Errorf("version = %q, %v", v, err)
+	}
+	if v, err := replay(t, "cli_version").GetCLIVersion(context.Background()); err != nil || v != "1.4.2" {
+		t.Errorf("cli_version = %q, %v", v, err)
+	}
+}
+
+func TestGetUsage(t *testing.T) {
+	got, err := replay(t, "usage").GetUsage(context.Background(), "at-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Plan != "team" || got.CreditsRemaining != 1240 || got.CreditsUsed != 760 || got.Sessions != 14 || got.PeriodEnd.Day() != 31 {
+		t.Errorf("usage: got %+v", got)
+	}
+	if _, err := replay(t, "usage_missing").GetUsage(context.Background(), "at-1"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("usage_missing: err = %v, want ErrNotSupported", err)
+	}
+
+	// Backends whose manifest does not list the endpoint are not called
+	h := newHTTP("http://127.0.0.1:1", manifest.HTTPEndpoints{})
+	if _, err := h.GetUsage(context.Background(), "at-1"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("unlisted endpoint: err = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestListSessions(t *testing.T) {
+	got, err := replay(t, "sessions").ListSessions(context.Background(), "at-1", 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].ClientSessionID != "20251020-101500-a1b2" || got[0].Rows != 4200 || got[1].Status != "failed" {
+		t.Errorf("sessions: got %+v", got)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrUnauthorized is returned when the backend rejects the access token.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// ErrNotSupported is returned for endpoints the manifest does not list.
+var ErrNotSupported = errors.New("not supported by this backend")
+
+// maxBodySize bounds the response bodies the client reads.
+const maxBodySize = 1 << 20
+
+// DecodeError reports a response body that matches none of the known
+// revisions of an endpoint's schema.
+type DecodeError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("%s: unexpected response: %v", e.Endpoint, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// StatusError reports an unexpected HTTP status.
+type StatusError struct {
+	Endpoint string
+	Code     int
+	Body     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s failed: %d %s", e.Endpoint, e.Code, e.Body)
+}
+
+// statusError reads a short excerpt of the body for the error message.
+func statusError(endpoint string, resp *http.Response) error {
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return &StatusError{Endpoint: endpoint, Code: resp.StatusCode, Body: strings.TrimSpace(string(b))}
+}
+
+// schema decodes one revision of a response body into T. In strict mode
+// unknown fields are rejected, so that a body is attributed to the revision
+// it was written for rather than to the first one sharing a field name.
+// Each endpoint lists its schemas newest first; the first one is the current
+// revision.
+type schema[T any] struct {
+	decode func(body []byte, strict bool) (T, error)
+}
+
+// direct decodes the body as T.
+func direct[T any]() schema[*T] {
+	return schema[*T]{decode: func(body []byte, strict bool) (*T, error) {
+		out := new(T)
+		return out, unmarshal(body, out, strict)
+	}}
+}
+
+// enveloped decodes a body of the form {"<key>": T}.
+func enveloped[T any](key string) schema[*T] {
+	return schema[*T]{decode: func(body []byte, strict bool) (*T, error) {
+		var env map[string]json.RawMessage
+		if err := json.Unmarshal(body, &env); err != nil {
+			return nil, err
+		}
+		raw, ok := env[key]
+		if !ok || (strict && len(env) != 1) {
+			return nil, fmt.Errorf("not a %q envelope", key)
+		}
+		out := new(T)
+		return out, unmarshal(raw, out, strict)
+	}}
+}
+
+// decodeResponse reads resp and decodes it with the first schema that accepts
+// it. All schemas are tried strictly first, then leniently, since newer
+// backends may add fields to the current revision. valid, when set, rejects
+// decoded values that lack required fields.
+func decodeResponse[T any](endpoint string, resp *http.Response, valid func(T) error, schemas ...schema[T]) (T, error) {
+	var zero T
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return zero, err
+	}
+	// Report why the current revision, decoded leniently, was rejected
+	var currentErr error
+	for _, strict := range []bool{true, false} {
+		for i, s := range schemas {
+			v, err := s.decode(body, strict)
+			if err == nil && valid != nil {
+				err = valid(v)
+			}
+			if err == nil {
+				return v, nil
+			}
+			if i == 0 && !strict {
+				currentErr = err
+			}
+		}
+	}
+	return zero, &DecodeError{Endpoint: endpoint, Err: currentErr}
+}
+
+// converted decodes a legacy body as L and converts it to the current type.
+func converted[L, T any](conv func(*L) *T) schema[*T] {
+	legacy := direct[L]()
+	return schema[*T]{decode: func(body []byte, strict bool) (*T, error) {
+		l, err := legacy.decode(body, strict)
+		if err != nil {
+			return nil, err
+		}
+		return conv(l), nil
+	}}
+}
+
+// unmarshal decodes a single JSON value, rejecting unknown fields when strict.
+func unmarshal(data []byte, v any, strict bool) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if strict {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("trailing data after JSON value")
+	}
+	return nil
+}
@@ -5,11 +5,11 @@ package backend
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 	"strings"
 	"time"
 
+	"seedfast/cli/internal/httpclient"
 	"seedfast/cli/internal/manifest"
 )
 
@@ -21,21 +21,22 @@ type HTTP struct {
 	baseURL string
 	// endpoints contains the URL paths for various API endpoints
 	endpoints manifest.HTTPEndpoints
-	// client is the underlying HTTP client with configured timeout
+	// client is the shared HTTP client with per-attempt timeout and retries
 	client *http.Client
 	// meCache stores user data from /api/cli/me for offline access
-	meCache map[string]any
+	meCache *User
 	// meCacheTime tracks when the cache was last updated
 	meCacheTime time.Time
 }
 
 // newHTTP creates a new HTTP client with the given base URL and endpoints.
-// It configures a 15-second timeout for all requests.
+// Each attempt is limited to 15 seconds; idempotent calls are retried on
+// transient failures (see package httpclient).
 func newHTTP(baseURL string, endpoints manifest.HTTPEndpoints) *HTTP {
 	return &HTTP{
 		baseURL:   strings.TrimRight(baseURL, "/"),
 		endpoints: endpoints,
-		client:    &http.Client{Timeout: 15 * time.Second},
+		client:    httpclient.New(httpclient.DefaultTimeout),
 	}
 }
 
@@ -47,6 +48,11 @@ func (h *HTTP) setStandardHeaders(req *http.Request) {
 	}
 }
 
+var versionSchemas = []schema[*VersionResponse]{
+	direct[VersionResponse](),
+	enveloped[VersionResponse]("data"),
+}
+
 // GetVersion calls GET /api/version and returns the backend version string when available.
 // No authentication required. This can be used to check connectivity to the backend service.
 func (h *HTTP) GetVersion(ctx context.Context) (string, error) {
@@ -64,10 +70,8 @@ func (h *HTTP) GetVersion(ctx context.Context) (string, error) {
 	if resp.StatusCode != http.StatusOK {
 		return "unknown", nil
 	}
-	var out struct {
-		Version string `json:"version"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+	out, err := decodeResponse("version", resp, nil, versionSchemas...)
+	if err != nil {
 		return "", err
 	}
 	if out.Version == "" {
@@ -98,10 +102,8 @@ func (h *HTTP) GetCLIVersion(ctx context.Context) (string, error) {
 	if resp.StatusCode != http.StatusOK {
 		return "", nil
 	}
-	var out struct {
-		Version string `json:"version"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+	out, err := decodeResponse("cli-version", resp, nil, versionSchemas...)
+	if err != nil {
 		return "", err
 	}
 	return out.Version, nil
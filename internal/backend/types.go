@@ -0,0 +1,132 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package backend
+
+import (
+	"errors"
+	"time"
+)
+
+// Request and response bodies of the endpoints listed in manifest.HTTPEndpoints.
+// Field names follow the current API revision; older revisions are accepted by
+// the fallback schemas in decode.go.
+
+// VersionResponse is returned by the version and cli_version endpoints.
+type VersionResponse struct {
+	Version string `json:"version"`
+}
+
+// DeviceLink is returned by the device_get_link endpoint and starts the
+// device authorization flow.
+type DeviceLink struct {
+	// Link is the URL the user opens to authorize this device
+	Link string `json:"link"`
+	// DeviceID identifies the pending authorization when polling token_issue
+	DeviceID string `json:"device_id"`
+	// PollInterval is the suggested polling interval in seconds (0 = default)
+	PollInterval int `json:"poll_interval,omitempty"`
+}
+
+func (d *DeviceLink) validate() error {
+	if d.Link == "" {
+		return errors.New("empty magic link")
+	}
+	return nil
+}
+
+// TokenRequest is the body of a token_issue request.
+type TokenRequest struct {
+	DeviceID string `json:"device_id"`
+}
+
+// RefreshRequest is the body of a token_refresh request.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Tokens is returned by the token_issue and token_refresh endpoints.
+// The refresh token is optional on refresh: the backend may keep the old one.
+type Tokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// ExpiresIn is the access token lifetime in seconds, when the backend reports it
+	ExpiresIn int    `json:"expires_in,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+}
+
+func (t *Tokens) validate() error {
+	if t.AccessToken == "" {
+		return errors.New("no access_token in response")
+	}
+	return nil
+}
+
+// DeviceConfirmation is returned by the device_confirm endpoint.
+type DeviceConfirmation struct {
+	UserID string `json:"user_id"`
+}
+
+func (d *DeviceConfirmation) validate() error {
+	if d.UserID == "" {
+		return errors.New("no user_id in response")
+	}
+	return nil
+}
+
+// User is returned by the account_whoami endpoint.
+type User struct {
+	ID     string `json:"id,omitempty"`
+	UserID string `json:"user_id,omitempty"`
+	Email  string `json:"email,omitempty"`
+	Name   string `json:"name,omitempty"`
+	// Plan is the subscription plan of the account, when reported
+	Plan string `json:"plan,omitempty"`
+}
+
+// Identifier returns the best identifier to show for the user: the email,
+// then the user ID, then the record ID. Empty when none is set.
+func (u *User) Identifier() string {
+	if u == nil {
+		return ""
+	}
+	for _, v := range []string{u.Email, u.UserID, u.ID} {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Usage is returned by the account_usage endpoint and describes the credits
+// of the current billing period.
+type Usage struct {
+	Plan             string    `json:"plan"`
+	CreditsRemaining int64     `json:"credits_remaining"`
+	CreditsIncluded  int64     `json:"credits_included,omitempty"`
+	CreditsUsed      int64     `json:"credits_used"`
+	PeriodStart      time.Time `json:"period_start,omitempty"`
+	PeriodEnd        time.Time `json:"period_end,omitempty"`
+	// Sessions and Rows count the seeding sessions and inserted rows this period
+	Sessions int   `json:"sessions"`
+	Rows     int64 `json:"rows"`
+}
+
+// Session is one seeding session as recorded by the backend.
+type Session struct {
+	ID string `json:"id"`
+	// ClientSessionID is the local session ID sent by the CLI, when known
+	ClientSessionID string    `json:"client_session_id,omitempty"`
+	Database        string    `json:"database"`
+	Tables          int       `json:"tables"`
+	Rows            int64     `json:"rows"`
+	StartedAt       time.Time `json:"started_at"`
+	DurationMS      int64     `json:"duration_ms"`
+	Status          string    `json:"status"`
+	Credits         int64     `json:"credits,omitempty"`
+}
+
+// SessionList is returned by the account_sessions endpoint, newest first.
+type SessionList struct {
+	Sessions []Session `json:"sessions"`
+}
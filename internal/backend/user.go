@@ -5,19 +5,31 @@ package backend
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"io"
 	"net/http"
-	"strings"
 	"time"
 )
 
+// legacyUser is the camelCase account response of the first API revision.
+type legacyUser struct {
+	ID     string `json:"id"`
+	UserID string `json:"userId"`
+	Email  string `json:"email"`
+	Name   string `json:"name"`
+}
+
+var userSchemas = []schema[*User]{
+	direct[User](),
+	enveloped[User]("user"),
+	enveloped[User]("data"),
+	converted(func(l *legacyUser) *User {
+		return &User{ID: l.ID, UserID: l.UserID, Email: l.Email, Name: l.Name}
+	}),
+}
+
 // GetMe calls GET /api/cli/me with Authorization header.
 // Results are cached in memory for 10 minutes to support offline mode and reduce API calls.
-// Returns user data as a map, or an error if the request fails and no cached data is available.
-func (h *HTTP) GetMe(ctx context.Context, accessToken string) (map[string]any, error) {
+// Returns the user, or an error if the request fails and no cached data is available.
+func (h *HTTP) GetMe(ctx context.Context, accessToken string) (*User, error) {
 	// Check cache first (10 minute TTL)
 	if h.meCache != nil && time.Since(h.meCacheTime) < 10*time.Minute {
 		return h.meCache, nil
@@ -52,14 +64,13 @@ func (h *HTTP) GetMe(ctx context.Context, accessToken string) (map[string]any, e
 			return h.meCache, nil
 		}
 		if resp.StatusCode == http.StatusUnauthorized {
-			return nil, errors.New("unauthorized")
+			return nil, ErrUnauthorized
 		}
-		b, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("get-me failed: %d %s", resp.StatusCode, strings.TrimSpace(string(b)))
+		return nil, statusError("get-me", resp)
 	}
 
-	var userData map[string]any
-	if err := json.NewDecoder(resp.Body).Decode(&userData); err != nil {
+	user, err := decodeResponse("get-me", resp, nil, userSchemas...)
+	if err != nil {
 		// Decode error - return cached data if available
 		if h.meCache != nil {
 			return h.meCache, nil
@@ -68,8 +79,8 @@ func (h *HTTP) GetMe(ctx context.Context, accessToken string) (map[string]any, e
 	}
 
 	// Update cache
-	h.meCache = userData
+	h.meCache = user
 	h.meCacheTime = time.Now()
 
-	return userData, nil
+	return user, nil
 }
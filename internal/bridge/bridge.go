@@ -30,7 +30,9 @@ type Bridge interface {
 	// Connect establishes transport to backend. addr is gRPC address when using gRPC implementation.
 	Connect(ctx context.Context, addr string, accessToken string) error
 	// Init sends initial session parameters (sessionID may be empty to create new).
-	Init(ctx context.Context, sessionID string, dbName string) error
+	// Backends that understand the options answer with a session_accepted event
+	// before any other; older backends ignore them.
+	Init(ctx context.Context, sessionID string, dbName string, opts model.SessionOptions) error
 	Close(ctx context.Context) error
 	// Events returns a stream of seeding/logging events from backend for rendering.
 	Events() <-chan seeding.Event
@@ -13,6 +13,7 @@ package grpcclient
 
 import (
     "context"
+    "encoding/json"
     "errors"
     "io"
     "crypto/tls"
@@ -20,6 +21,7 @@ import (
     "net"
 
     "seedfast/cli/internal/bridge/model"
+    "seedfast/cli/internal/httpclient"
     "seedfast/cli/internal/seeding"
 
     dbpb "seedfast/cli/internal/bridge/proto"
@@ -60,12 +62,16 @@ func (c *Client) Connect(ctx context.Context, addr string, accessToken string) e
         target = net.JoinHostPort(addr, "443")
     }
 
-    tlsCfg := &tls.Config{ ServerName: host, MinVersion: tls.VersionTLS12 }
+    // Trust the same additional CA bundle as HTTP calls (SEEDFAST_CA_FILE)
+    roots, err := httpclient.RootCAs()
+    if err != nil {
+        return err
+    }
+    tlsCfg := &tls.Config{ ServerName: host, MinVersion: tls.VersionTLS12, RootCAs: roots }
     creds := credentials.NewTLS(tlsCfg)
     dctx, cancel := context.WithTimeout(ctx, 10*time.Second)
     defer cancel()
 
-    var err error
     c.conn, err = grpc.DialContext(dctx, target, grpc.WithTransportCredentials(creds), grpc.WithBlock())
 	if err != nil {
 		return err
@@ -88,7 +94,7 @@ func (c *Client) Connect(ctx context.Context, addr string, accessToken string) e
 }
 
 // Init sends initial session parameters and starts receiving.
-func (c *Client) Init(ctx context.Context, sessionID string, dbName string) error {
+func (c *Client) Init(ctx context.Context, sessionID string, dbName string, opts model.SessionOptions) error {
 	if c.stream == nil {
 		return errors.New("stream not initialized")
 	}
@@ -98,7 +104,29 @@ func (c *Client) Init(ctx context.Context, sessionID string, dbName string) erro
 	if dbName == "" {
 		return errors.New("dbName is required (cannot be empty)")
 	}
-	if err := c.stream.Send(&dbpb.ClientMessage{Message: &dbpb.ClientMessage_Init{Init: &dbpb.InitRequest{SessionId: sessionID, DbName: dbName}}}); err != nil {
+	// The dialect is also sent outside the options for servers predating them
+	req := &dbpb.InitRequest{
+		SessionId: sessionID,
+		DbName:    dbName,
+		Dialect:   opts.Dialect,
+		Options: &dbpb.SessionOptions{
+			Version:         model.OptionsVersion,
+			CliVersion:      opts.CLIVersion,
+			ClientSessionId: opts.ClientSessionID,
+			Dialect:         opts.Dialect,
+			ServerVersion:   opts.ServerVersion,
+			Locale:          opts.Locale,
+			DryRun:          opts.DryRun,
+			Schemas:         opts.Scope.Schemas,
+			ExcludeSchemas:  opts.Scope.ExcludeSchemas,
+			Tables:          opts.Scope.Tables,
+			ExcludeTables:   opts.Scope.ExcludeTables,
+			RowTargets:      opts.Planning.Rows,
+			Scale:           opts.Planning.Scale,
+			Capabilities:    []string{model.CapabilityLocalScope, model.CapabilityRowsAffected},
+		},
+	}
+	if err := c.stream.Send(&dbpb.ClientMessage{Message: &dbpb.ClientMessage_Init{Init: req}}); err != nil {
 		return err
 	}
 	go c.receiveLoop()
@@ -169,6 +197,14 @@ func (c *Client) receiveLoop() {
 		case *dbpb.ServerMessage_UiEvent:
 			u := m.UiEvent
 			c.events <- seeding.Event{Type: seeding.EventType(u.EventType), Message: u.PayloadJson}
+		case *dbpb.ServerMessage_SessionAccepted:
+			a := m.SessionAccepted
+			payload, _ := json.Marshal(model.Negotiation{
+				OptionsVersion: a.OptionsVersion,
+				HonoredOptions: a.HonoredOptions,
+				Capabilities:   a.Capabilities,
+			})
+			c.events <- seeding.Event{Type: seeding.EventType(seeding.BackendEventSessionAccepted), Message: string(payload)}
 		}
 	}
 }
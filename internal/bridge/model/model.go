@@ -19,6 +19,110 @@ type SQLTask struct {
 	Schema       string // Database schema to use for the query
 }
 
+// Scope limits what the backend may plan. Entries are names or glob patterns;
+// table entries containing a dot match schema-qualified names. Empty include
+// lists allow everything.
+type Scope struct {
+	Schemas        []string
+	ExcludeSchemas []string
+	Tables         []string
+	ExcludeTables  []string
+}
+
+// Planning holds the data volume requested for the plan.
+type Planning struct {
+	// Scale is "small", "medium" or "large"; empty leaves the choice to the backend
+	Scale string
+	// Rows maps table names, optionally schema-qualified, to the number of rows to generate
+	Rows map[string]int64
+}
+
+// OptionsVersion is the version of the session options schema sent by this client.
+const OptionsVersion = 1
+
+// Session options that need backend support. Servers list the ones they apply
+// in the negotiation result.
+const (
+	OptionScope      = "scope"
+	OptionRowTargets = "row_targets"
+	OptionScale      = "scale"
+	OptionLocale     = "locale"
+	OptionDryRun     = "dry_run"
+)
+
+// Capabilities announced by this client in the session options.
+const (
+	// CapabilityLocalScope means writes outside the scope are rejected locally
+	CapabilityLocalScope = "local_scope"
+	// CapabilityRowsAffected means write results report rows_affected
+	CapabilityRowsAffected = "rows_affected"
+)
+
+// SessionOptions are sent to the backend when a session starts.
+type SessionOptions struct {
+	CLIVersion string
+	// ClientSessionID is the local session ID, as listed by 'seedfast history'
+	ClientSessionID string
+	// Dialect names the SQL dialect of the target database (see dsn.DBType)
+	Dialect string
+	// ServerVersion is the version of the target database server, if known
+	ServerVersion string
+	// Locale selects the locale of generated data, e.g. "de-DE"
+	Locale string
+	// DryRun asks for a plan only; the client executes no writes
+	DryRun   bool
+	Scope    Scope
+	Planning Planning
+}
+
+// Requested lists the options set in o that need backend support.
+func (o SessionOptions) Requested() []string {
+	var out []string
+	s := o.Scope
+	if len(s.Schemas)+len(s.ExcludeSchemas)+len(s.Tables)+len(s.ExcludeTables) > 0 {
+		out = append(out, OptionScope)
+	}
+	if len(o.Planning.Rows) > 0 {
+		out = append(out, OptionRowTargets)
+	}
+	if o.Planning.Scale != "" {
+		out = append(out, OptionScale)
+	}
+	if o.Locale != "" {
+		out = append(out, OptionLocale)
+	}
+	if o.DryRun {
+		out = append(out, OptionDryRun)
+	}
+	return out
+}
+
+// Negotiation is the backend's answer to the session options.
+type Negotiation struct {
+	// OptionsVersion is the highest options schema version the backend understands
+	OptionsVersion uint32   `json:"options_version"`
+	HonoredOptions []string `json:"honored_options"`
+	Capabilities   []string `json:"capabilities"`
+}
+
+// Ignored returns the requested options the backend does not apply.
+func (n Negotiation) Ignored(requested []string) []string {
+	var out []string
+	for _, opt := range requested {
+		honored := false
+		for _, h := range n.HonoredOptions {
+			if h == opt {
+				honored = true
+				break
+			}
+		}
+		if !honored {
+			out = append(out, opt)
+		}
+	}
+	return out
+}
+
 // SQLResponse is the result of executing an SQLTask.
 type SQLResponse struct {
 	RequestID  string
@@ -113,6 +113,7 @@ type ServerMessage struct {
 	//
 	//	*ServerMessage_SqlRequest
 	//	*ServerMessage_UiEvent
+	//	*ServerMessage_SessionAccepted
 	Message isServerMessage_Message `protobuf_oneof:"message"`
 }
 
@@ -169,6 +170,13 @@ func (x *ServerMessage) GetUiEvent() *UIEvent {
 	return nil
 }
 
+func (x *ServerMessage) GetSessionAccepted() *SessionAccepted {
+	if x, ok := x.GetMessage().(*ServerMessage_SessionAccepted); ok {
+		return x.SessionAccepted
+	}
+	return nil
+}
+
 type isServerMessage_Message interface {
 	isServerMessage_Message()
 }
@@ -181,18 +189,26 @@ type ServerMessage_UiEvent struct {
 	UiEvent *UIEvent `protobuf:"bytes,2,opt,name=ui_event,json=uiEvent,proto3,oneof"` // UI event for display
 }
 
+type ServerMessage_SessionAccepted struct {
+	SessionAccepted *SessionAccepted `protobuf:"bytes,3,opt,name=session_accepted,json=sessionAccepted,proto3,oneof"` // Options negotiation result, sent first by servers supporting SessionOptions
+}
+
 func (*ServerMessage_SqlRequest) isServerMessage_Message() {}
 
 func (*ServerMessage_UiEvent) isServerMessage_Message() {}
 
+func (*ServerMessage_SessionAccepted) isServerMessage_Message() {}
+
 // Session initialization message
 type InitRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // Client session ID (empty to create new)
-	DbName    string `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`          // Database name to seed
+	SessionId string          `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // Client session ID (empty to create new)
+	DbName    string          `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`          // Database name to seed
+	Dialect   string          `protobuf:"bytes,3,opt,name=dialect,proto3" json:"dialect,omitempty"`                      // SQL dialect of the target database ("postgresql" when empty, "mysql")
+	Options   *SessionOptions `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`                      // Session options; ignored by older servers
 }
 
 func (x *InitRequest) Reset() {
@@ -241,6 +257,238 @@ func (x *InitRequest) GetDbName() string {
 	return ""
 }
 
+func (x *InitRequest) GetDialect() string {
+	if x != nil {
+		return x.Dialect
+	}
+	return ""
+}
+
+func (x *InitRequest) GetOptions() *SessionOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// Versioned session options. Servers apply the options they know and report
+// them in SessionAccepted; unknown fields are ignored.
+type SessionOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Schemas         []string         `protobuf:"bytes,1,rep,name=schemas,proto3" json:"schemas,omitempty"`                                                                                                                  // Schemas the plan may include (all when empty)
+	ExcludeSchemas  []string         `protobuf:"bytes,2,rep,name=exclude_schemas,json=excludeSchemas,proto3" json:"exclude_schemas,omitempty"`                                                                              // Schemas the plan must not include
+	Tables          []string         `protobuf:"bytes,3,rep,name=tables,proto3" json:"tables,omitempty"`                                                                                                                    // Table patterns the plan may include (all when empty)
+	ExcludeTables   []string         `protobuf:"bytes,4,rep,name=exclude_tables,json=excludeTables,proto3" json:"exclude_tables,omitempty"`                                                                                 // Table patterns the plan must not include
+	RowTargets      map[string]int64 `protobuf:"bytes,5,rep,name=row_targets,json=rowTargets,proto3" json:"row_targets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"` // Rows to generate per table, keyed by table name
+	Scale           string           `protobuf:"bytes,6,opt,name=scale,proto3" json:"scale,omitempty"`                                                                                                                      // Default data volume: "small", "medium" or "large" (backend default when empty)
+	Version         uint32           `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`                                                                                                                 // Options schema version understood by the client
+	CliVersion      string           `protobuf:"bytes,8,opt,name=cli_version,json=cliVersion,proto3" json:"cli_version,omitempty"`                                                                                          // Version of the CLI, e.g. "1.4.0"
+	ClientSessionId string           `protobuf:"bytes,9,opt,name=client_session_id,json=clientSessionId,proto3" json:"client_session_id,omitempty"`                                                                         // Local session ID of the CLI, as listed by 'seedfast history'
+	Dialect         string           `protobuf:"bytes,10,opt,name=dialect,proto3" json:"dialect,omitempty"`                                                                                                                 // SQL dialect of the target database
+	ServerVersion   string           `protobuf:"bytes,11,opt,name=server_version,json=serverVersion,proto3" json:"server_version,omitempty"`                                                                                // Version of the target database server, e.g. "16.3"
+	Locale          string           `protobuf:"bytes,12,opt,name=locale,proto3" json:"locale,omitempty"`                                                                                                                   // Locale of generated data, e.g. "de-DE" (backend default when empty)
+	DryRun          bool             `protobuf:"varint,13,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                                                                                                    // Plan only; the client executes no writes
+	Capabilities    []string         `protobuf:"bytes,14,rep,name=capabilities,proto3" json:"capabilities,omitempty"`                                                                                                       // Optional protocol features the client supports
+}
+
+func (x *SessionOptions) Reset() {
+	*x = SessionOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_bridge_proto_database_bridge_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionOptions) ProtoMessage() {}
+
+func (x *SessionOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_bridge_proto_database_bridge_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionOptions.ProtoReflect.Descriptor instead.
+func (*SessionOptions) Descriptor() ([]byte, []int) {
+	return file_internal_bridge_proto_database_bridge_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SessionOptions) GetSchemas() []string {
+	if x != nil {
+		return x.Schemas
+	}
+	return nil
+}
+
+func (x *SessionOptions) GetExcludeSchemas() []string {
+	if x != nil {
+		return x.ExcludeSchemas
+	}
+	return nil
+}
+
+func (x *SessionOptions) GetTables() []string {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+func (x *SessionOptions) GetExcludeTables() []string {
+	if x != nil {
+		return x.ExcludeTables
+	}
+	return nil
+}
+
+func (x *SessionOptions) GetRowTargets() map[string]int64 {
+	if x != nil {
+		return x.RowTargets
+	}
+	return nil
+}
+
+func (x *SessionOptions) GetScale() string {
+	if x != nil {
+		return x.Scale
+	}
+	return ""
+}
+
+func (x *SessionOptions) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SessionOptions) GetCliVersion() string {
+	if x != nil {
+		return x.CliVersion
+	}
+	return ""
+}
+
+func (x *SessionOptions) GetClientSessionId() string {
+	if x != nil {
+		return x.ClientSessionId
+	}
+	return ""
+}
+
+func (x *SessionOptions) GetDialect() string {
+	if x != nil {
+		return x.Dialect
+	}
+	return ""
+}
+
+func (x *SessionOptions) GetServerVersion() string {
+	if x != nil {
+		return x.ServerVersion
+	}
+	return ""
+}
+
+func (x *SessionOptions) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *SessionOptions) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *SessionOptions) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+// Options negotiation result, sent by servers that understand SessionOptions
+// before any other message. Older servers never send it.
+type SessionAccepted struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OptionsVersion uint32   `protobuf:"varint,1,opt,name=options_version,json=optionsVersion,proto3" json:"options_version,omitempty"` // Highest options schema version the server understands
+	HonoredOptions []string `protobuf:"bytes,2,rep,name=honored_options,json=honoredOptions,proto3" json:"honored_options,omitempty"`  // Options the server applies, e.g. "scope", "row_targets"
+	Capabilities   []string `protobuf:"bytes,3,rep,name=capabilities,proto3" json:"capabilities,omitempty"`                            // Client capabilities the server will use
+}
+
+func (x *SessionAccepted) Reset() {
+	*x = SessionAccepted{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_bridge_proto_database_bridge_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionAccepted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionAccepted) ProtoMessage() {}
+
+func (x *SessionAccepted) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_bridge_proto_database_bridge_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionAccepted.ProtoReflect.Descriptor instead.
+func (*SessionAccepted) Descriptor() ([]byte, []int) {
+	return file_internal_bridge_proto_database_bridge_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SessionAccepted) GetOptionsVersion() uint32 {
+	if x != nil {
+		return x.OptionsVersion
+	}
+	return 0
+}
+
+func (x *SessionAccepted) GetHonoredOptions() []string {
+	if x != nil {
+		return x.HonoredOptions
+	}
+	return nil
+}
+
+func (x *SessionAccepted) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
 // SQL execution messages
 type SQLRequest struct {
 	state         protoimpl.MessageState
@@ -256,7 +504,7 @@ type SQLRequest struct {
 func (x *SQLRequest) Reset() {
 	*x = SQLRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_bridge_proto_database_bridge_proto_msgTypes[3]
+		mi := &file_internal_bridge_proto_database_bridge_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -269,7 +517,7 @@ func (x *SQLRequest) String() string {
 func (*SQLRequest) ProtoMessage() {}
 
 func (x *SQLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_bridge_proto_database_bridge_proto_msgTypes[3]
+	mi := &file_internal_bridge_proto_database_bridge_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -282,7 +530,7 @@ func (x *SQLRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLRequest.ProtoReflect.Descriptor instead.
 func (*SQLRequest) Descriptor() ([]byte, []int) {
-	return file_internal_bridge_proto_database_bridge_proto_rawDescGZIP(), []int{3}
+	return file_internal_bridge_proto_database_bridge_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *SQLRequest) GetRequestId() string {
@@ -306,6 +554,13 @@ func (x *SQLRequest) GetIsWrite() bool {
 	return false
 }
 
+func (x *SQLRequest) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
 type SQLResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -319,7 +574,7 @@ type SQLResponse struct {
 func (x *SQLResponse) Reset() {
 	*x = SQLResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_bridge_proto_database_bridge_proto_msgTypes[4]
+		mi := &file_internal_bridge_proto_database_bridge_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -332,7 +587,7 @@ func (x *SQLResponse) String() string {
 func (*SQLResponse) ProtoMessage() {}
 
 func (x *SQLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_bridge_proto_database_bridge_proto_msgTypes[4]
+	mi := &file_internal_bridge_proto_database_bridge_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -345,7 +600,7 @@ func (x *SQLResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLResponse.ProtoReflect.Descriptor instead.
 func (*SQLResponse) Descriptor() ([]byte, []int) {
-	return file_internal_bridge_proto_database_bridge_proto_rawDescGZIP(), []int{4}
+	return file_internal_bridge_proto_database_bridge_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *SQLResponse) GetRequestId() string {
@@ -382,7 +637,7 @@ type UIEvent struct {
 func (x *UIEvent) Reset() {
 	*x = UIEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_bridge_proto_database_bridge_proto_msgTypes[5]
+		mi := &file_internal_bridge_proto_database_bridge_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -395,7 +650,7 @@ func (x *UIEvent) String() string {
 func (*UIEvent) ProtoMessage() {}
 
 func (x *UIEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_bridge_proto_database_bridge_proto_msgTypes[5]
+	mi := &file_internal_bridge_proto_database_bridge_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -408,7 +663,7 @@ func (x *UIEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UIEvent.ProtoReflect.Descriptor instead.
 func (*UIEvent) Descriptor() ([]byte, []int) {
-	return file_internal_bridge_proto_database_bridge_proto_rawDescGZIP(), []int{5}
+	return file_internal_bridge_proto_database_bridge_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UIEvent) GetEventType() string {
@@ -441,7 +696,7 @@ var file_internal_bridge_proto_database_bridge_proto_rawDesc = []byte{
 	0x61, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x53, 0x51, 0x4c,
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x71, 0x6c, 0x52,
 	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x22, 0x91, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73,
+	0x67, 0x65, 0x22, 0xe0, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73,
 	0x73, 0x61, 0x67, 0x65, 0x12, 0x3e, 0x0a, 0x0b, 0x73, 0x71, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x61, 0x74, 0x61,
 	0x62, 0x61, 0x73, 0x65, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x53, 0x51, 0x4c, 0x52,
@@ -449,40 +704,96 @@ var file_internal_bridge_proto_database_bridge_proto_rawDesc = []byte{
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x08, 0x75, 0x69, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
 	0x65, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x55, 0x49, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x48, 0x00, 0x52, 0x07, 0x75, 0x69, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x45, 0x0a, 0x0b, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65,
+	0x48, 0x00, 0x52, 0x07, 0x75, 0x69, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x4d, 0x0a, 0x10, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41,
+	0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x9a, 0x01, 0x0a, 0x0b, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
 	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
 	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x62, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x62, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x6b, 0x0a,
-	0x0a, 0x53, 0x51, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x71,
-	0x6c, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0c, 0x73, 0x71, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12,
-	0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x07, 0x69, 0x73, 0x57, 0x72, 0x69, 0x74, 0x65, 0x22, 0x67, 0x0a, 0x0b, 0x53, 0x51,
-	0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63,
-	0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x6a, 0x73, 0x6f,
-	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4a,
-	0x73, 0x6f, 0x6e, 0x22, 0x4b, 0x0a, 0x07, 0x55, 0x49, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1d,
-	0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a,
-	0x0c, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x4a, 0x73, 0x6f, 0x6e,
-	0x32, 0x62, 0x0a, 0x0e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x42, 0x72, 0x69, 0x64,
-	0x67, 0x65, 0x12, 0x50, 0x0a, 0x0a, 0x52, 0x75, 0x6e, 0x53, 0x65, 0x65, 0x64, 0x69, 0x6e, 0x67,
-	0x12, 0x1e, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x62, 0x72, 0x69, 0x64,
-	0x67, 0x65, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x1a, 0x1e, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x62, 0x72, 0x69, 0x64,
-	0x67, 0x65, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x28, 0x01, 0x30, 0x01, 0x42, 0x2a, 0x5a, 0x28, 0x73, 0x65, 0x65, 0x64, 0x66, 0x61, 0x73, 0x74,
-	0x2f, 0x63, 0x6c, 0x69, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x62, 0x72,
-	0x69, 0x64, 0x67, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x62, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x64, 0x69, 0x61, 0x6c, 0x65, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x64, 0x69, 0x61, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x39, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62,
+	0x61, 0x73, 0x65, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x22, 0xb6, 0x04, 0x0a, 0x0e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x12,
+	0x27, 0x0a, 0x0f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73,
+	0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x50, 0x0a, 0x0b, 0x72, 0x6f, 0x77, 0x5f, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x6f,
+	0x77, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x72,
+	0x6f, 0x77, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x61,
+	0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6c, 0x69,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x63, 0x6c, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x61, 0x6c, 0x65, 0x63,
+	0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x69, 0x61, 0x6c, 0x65, 0x63, 0x74,
+	0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x12,
+	0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c,
+	0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x1a, 0x3d, 0x0a, 0x0f,
+	0x52, 0x6f, 0x77, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x87, 0x01, 0x0a, 0x0f,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12,
+	0x27, 0x0a, 0x0f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x68, 0x6f, 0x6e, 0x6f,
+	0x72, 0x65, 0x64, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0e, 0x68, 0x6f, 0x6e, 0x6f, 0x72, 0x65, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x0a, 0x53, 0x51, 0x4c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x71, 0x6c, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x71, 0x6c, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x77,
+	0x72, 0x69, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x22, 0x67, 0x0a, 0x0b, 0x53,
+	0x51, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x6a, 0x73,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x4a, 0x73, 0x6f, 0x6e, 0x22, 0x4b, 0x0a, 0x07, 0x55, 0x49, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x21,
+	0x0a, 0x0c, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x4a, 0x73, 0x6f,
+	0x6e, 0x32, 0x62, 0x0a, 0x0e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x42, 0x72, 0x69,
+	0x64, 0x67, 0x65, 0x12, 0x50, 0x0a, 0x0a, 0x52, 0x75, 0x6e, 0x53, 0x65, 0x65, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x1e, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x62, 0x72, 0x69,
+	0x64, 0x67, 0x65, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x1a, 0x1e, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x62, 0x72, 0x69,
+	0x64, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x2a, 0x5a, 0x28, 0x73, 0x65, 0x65, 0x64, 0x66, 0x61, 0x73,
+	0x74, 0x2f, 0x63, 0x6c, 0x69, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x62,
+	0x72, 0x69, 0x64, 0x67, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -497,27 +808,33 @@ func file_internal_bridge_proto_database_bridge_proto_rawDescGZIP() []byte {
 	return file_internal_bridge_proto_database_bridge_proto_rawDescData
 }
 
-var file_internal_bridge_proto_database_bridge_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_internal_bridge_proto_database_bridge_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_internal_bridge_proto_database_bridge_proto_goTypes = []any{
-	(*ClientMessage)(nil), // 0: database_bridge.ClientMessage
-	(*ServerMessage)(nil), // 1: database_bridge.ServerMessage
-	(*InitRequest)(nil),   // 2: database_bridge.InitRequest
-	(*SQLRequest)(nil),    // 3: database_bridge.SQLRequest
-	(*SQLResponse)(nil),   // 4: database_bridge.SQLResponse
-	(*UIEvent)(nil),       // 5: database_bridge.UIEvent
+	(*ClientMessage)(nil),   // 0: database_bridge.ClientMessage
+	(*ServerMessage)(nil),   // 1: database_bridge.ServerMessage
+	(*InitRequest)(nil),     // 2: database_bridge.InitRequest
+	(*SessionOptions)(nil),  // 3: database_bridge.SessionOptions
+	(*SessionAccepted)(nil), // 4: database_bridge.SessionAccepted
+	(*SQLRequest)(nil),      // 5: database_bridge.SQLRequest
+	(*SQLResponse)(nil),     // 6: database_bridge.SQLResponse
+	(*UIEvent)(nil),         // 7: database_bridge.UIEvent
+	nil,                     // 8: database_bridge.SessionOptions.RowTargetsEntry
 }
 var file_internal_bridge_proto_database_bridge_proto_depIdxs = []int32{
 	2, // 0: database_bridge.ClientMessage.init:type_name -> database_bridge.InitRequest
-	4, // 1: database_bridge.ClientMessage.sql_response:type_name -> database_bridge.SQLResponse
-	3, // 2: database_bridge.ServerMessage.sql_request:type_name -> database_bridge.SQLRequest
-	5, // 3: database_bridge.ServerMessage.ui_event:type_name -> database_bridge.UIEvent
-	0, // 4: database_bridge.DatabaseBridge.RunSeeding:input_type -> database_bridge.ClientMessage
-	1, // 5: database_bridge.DatabaseBridge.RunSeeding:output_type -> database_bridge.ServerMessage
-	5, // [5:6] is the sub-list for method output_type
-	4, // [4:5] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	6, // 1: database_bridge.ClientMessage.sql_response:type_name -> database_bridge.SQLResponse
+	5, // 2: database_bridge.ServerMessage.sql_request:type_name -> database_bridge.SQLRequest
+	7, // 3: database_bridge.ServerMessage.ui_event:type_name -> database_bridge.UIEvent
+	4, // 4: database_bridge.ServerMessage.session_accepted:type_name -> database_bridge.SessionAccepted
+	3, // 5: database_bridge.InitRequest.options:type_name -> database_bridge.SessionOptions
+	8, // 6: database_bridge.SessionOptions.row_targets:type_name -> database_bridge.SessionOptions.RowTargetsEntry
+	0, // 7: database_bridge.DatabaseBridge.RunSeeding:input_type -> database_bridge.ClientMessage
+	1, // 8: database_bridge.DatabaseBridge.RunSeeding:output_type -> database_bridge.ServerMessage
+	8, // [8:9] is the sub-list for method output_type
+	7, // [7:8] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_internal_bridge_proto_database_bridge_proto_init() }
@@ -563,7 +880,7 @@ func file_internal_bridge_proto_database_bridge_proto_init() {
 			}
 		}
 		file_internal_bridge_proto_database_bridge_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*SQLRequest); i {
+			switch v := v.(*SessionOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -575,7 +892,7 @@ func file_internal_bridge_proto_database_bridge_proto_init() {
 			}
 		}
 		file_internal_bridge_proto_database_bridge_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*SQLResponse); i {
+			switch v := v.(*SessionAccepted); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -587,6 +904,30 @@ func file_internal_bridge_proto_database_bridge_proto_init() {
 			}
 		}
 		file_internal_bridge_proto_database_bridge_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*SQLRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_bridge_proto_database_bridge_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*SQLResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_bridge_proto_database_bridge_proto_msgTypes[7].Exporter = func(v any, i int) any {
 			switch v := v.(*UIEvent); i {
 			case 0:
 				return &v.state
@@ -606,6 +947,7 @@ func file_internal_bridge_proto_database_bridge_proto_init() {
 	file_internal_bridge_proto_database_bridge_proto_msgTypes[1].OneofWrappers = []any{
 		(*ServerMessage_SqlRequest)(nil),
 		(*ServerMessage_UiEvent)(nil),
+		(*ServerMessage_SessionAccepted)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -613,7 +955,7 @@ func file_internal_bridge_proto_database_bridge_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_bridge_proto_database_bridge_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
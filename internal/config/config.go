@@ -0,0 +1,659 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+// Package config loads the optional seedfast.yaml configuration files.
+//
+// A project file is discovered by walking from the working directory up to the
+// filesystem root; the nearest seedfast.yaml (or seedfast.yml) wins. A user file
+// may live in the Seedfast user directory (see internal/userdir). Settings are
+// resolved with the precedence
+//
+//	flags > environment > project file > user file > built-in defaults
+//
+// Flags are applied by the commands themselves; this package merges the other
+// layers and records where every value came from so 'seedfast config show' can
+// explain the result.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"seedfast/cli/internal/keychain"
+	"seedfast/cli/internal/userdir"
+
+	"gopkg.in/yaml.v3"
+)
+
+// localeRegex matches language tags such as "en", "en-US" or "pt_BR".
+var localeRegex = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z0-9]{2,8})*$`)
+
+// File names searched for, in order, in every directory.
+var FileNames = []string{"seedfast.yaml", "seedfast.yml"}
+
+// Output formats.
+const (
+	OutputText  = "text"
+	OutputPlain = "plain"
+	OutputJSON  = "json"
+)
+
+// Data volume presets for the seeding plan.
+const (
+	ScaleSmall  = "small"
+	ScaleMedium = "medium"
+	ScaleLarge  = "large"
+)
+
+// Worker count limits.
+const (
+	DefaultWorkers = 4
+	MaxWorkers     = 64
+)
+
+// DefaultTrackingKeep is the number of sessions whose tracked rows are kept.
+const DefaultTrackingKeep = 20
+
+// Environment variables overriding the configuration files.
+const (
+	EnvConnection        = "SEEDFAST_CONNECTION"
+	EnvSchemas           = "SEEDFAST_SCHEMAS"
+	EnvExcludeSchemas    = "SEEDFAST_EXCLUDE_SCHEMAS"
+	EnvTables            = "SEEDFAST_TABLES"
+	EnvExcludeTables     = "SEEDFAST_EXCLUDE_TABLES"
+	EnvScale             = "SEEDFAST_SCALE"
+	EnvRows              = "SEEDFAST_ROWS"
+	EnvLocale            = "SEEDFAST_LOCALE"
+	EnvAskHumanAnswer    = "SEEDFAST_ASK_HUMAN_ANSWER"
+	EnvWorkers           = "SEEDFAST_WORKERS"
+	EnvProductionPattern = "SEEDFAST_PRODUCTION_PATTERN"
+	EnvOutput            = "SEEDFAST_OUTPUT"
+)
+
+// Config is the content of a seedfast.yaml file. Zero values mean "not set".
+type Config struct {
+	// Connection names the connection profile used by default (see 'seedfast connections')
+	Connection string `yaml:"connection" json:"connection,omitempty"`
+	// Schemas limits which schemas seeding may write to
+	Schemas Schemas `yaml:"schemas" json:"schemas"`
+	// Tables limits which tables seeding may write to
+	Tables Tables `yaml:"tables" json:"tables"`
+	// Scale is the default data volume: small, medium or large
+	Scale string `yaml:"scale" json:"scale,omitempty"`
+	// Rows maps table names to the number of rows to generate
+	Rows map[string]int64 `yaml:"rows" json:"rows,omitempty"`
+	// Locale selects the locale of generated data, e.g. "de-DE"
+	Locale string `yaml:"locale" json:"locale,omitempty"`
+	// AskHuman holds answers given automatically to planning questions
+	AskHuman AskHuman `yaml:"ask_human" json:"ask_human"`
+	// Workers is the number of SQL tasks executed concurrently
+	Workers int `yaml:"workers" json:"workers,omitempty"`
+	// Safety tunes the production safeguard
+	Safety Safety `yaml:"safety" json:"safety"`
+	// Output selects the output format: text, plain or json
+	Output string `yaml:"output" json:"output,omitempty"`
+	// Tracking configures the inserted rows recorded for 'seedfast undo'
+	Tracking Tracking `yaml:"tracking" json:"tracking"`
+}
+
+// Schemas lists schemas to include or exclude. An empty Include allows all schemas.
+// Entries are schema names or glob patterns (see path.Match), e.g. "tenant_*".
+type Schemas struct {
+	Include []string `yaml:"include" json:"include,omitempty"`
+	Exclude []string `yaml:"exclude" json:"exclude,omitempty"`
+}
+
+// Tables lists tables to include or exclude. An empty Include allows all tables.
+// Entries are table names or glob patterns; entries containing a dot match the
+// schema-qualified name (e.g. "billing.*"), others the table name in any schema.
+type Tables struct {
+	Include []string `yaml:"include" json:"include,omitempty"`
+	Exclude []string `yaml:"exclude" json:"exclude,omitempty"`
+}
+
+// AskHuman configures automatic answers to the questions asked while planning.
+type AskHuman struct {
+	// DefaultAnswer is "yes" to accept, "no" to reject, or free-form feedback.
+	// Empty means the question is asked interactively.
+	DefaultAnswer string `yaml:"default_answer" json:"default_answer,omitempty"`
+}
+
+// Safety overrides the production safeguard defaults (see internal/safety).
+type Safety struct {
+	ProductionPattern string `yaml:"production_pattern" json:"production_pattern,omitempty"`
+	MaxDatabaseBytes  *int64 `yaml:"max_database_bytes" json:"max_database_bytes,omitempty"`
+	MaxTableRows      *int64 `yaml:"max_table_rows" json:"max_table_rows,omitempty"`
+}
+
+// Tracking configures row tracking (see internal/tracking).
+type Tracking struct {
+	// Enabled turns row tracking off when false; unset means enabled
+	Enabled *bool `yaml:"enabled" json:"enabled,omitempty"`
+	// Keep is the number of most recent sessions whose tracked rows are kept
+	Keep int `yaml:"keep" json:"keep,omitempty"`
+}
+
+// Setting keys, in display order.
+const (
+	KeyConnection        = "connection"
+	KeySchemasInclude    = "schemas.include"
+	KeySchemasExclude    = "schemas.exclude"
+	KeyTablesInclude     = "tables.include"
+	KeyTablesExclude     = "tables.exclude"
+	KeyScale             = "scale"
+	KeyRows              = "rows"
+	KeyLocale            = "locale"
+	KeyAskHumanAnswer    = "ask_human.default_answer"
+	KeyWorkers           = "workers"
+	KeyProductionPattern = "safety.production_pattern"
+	KeyMaxDatabaseBytes  = "safety.max_database_bytes"
+	KeyMaxTableRows      = "safety.max_table_rows"
+	KeyOutput            = "output"
+	KeyTrackingEnabled   = "tracking.enabled"
+	KeyTrackingKeep      = "tracking.keep"
+)
+
+// Keys lists every setting key in display order.
+var Keys = []string{
+	KeyConnection, KeySchemasInclude, KeySchemasExclude, KeyTablesInclude, KeyTablesExclude, KeyScale, KeyRows,
+	KeyLocale, KeyAskHumanAnswer, KeyWorkers, KeyProductionPattern, KeyMaxDatabaseBytes, KeyMaxTableRows, KeyOutput,
+	KeyTrackingEnabled, KeyTrackingKeep,
+}
+
+// Value returns the setting key formatted for display, or "" when unset.
+func (c Config) Value(key string) string {
+	switch key {
+	case KeyConnection:
+		return c.Connection
+	case KeySchemasInclude:
+		return strings.Join(c.Schemas.Include, ", ")
+	case KeySchemasExclude:
+		return strings.Join(c.Schemas.Exclude, ", ")
+	case KeyTablesInclude:
+		return strings.Join(c.Tables.Include, ", ")
+	case KeyTablesExclude:
+		return strings.Join(c.Tables.Exclude, ", ")
+	case KeyScale:
+		return c.Scale
+	case KeyRows:
+		return FormatRows(c.Rows)
+	case KeyLocale:
+		return c.Locale
+	case KeyAskHumanAnswer:
+		return c.AskHuman.DefaultAnswer
+	case KeyWorkers:
+		if c.Workers > 0 {
+			return strconv.Itoa(c.Workers)
+		}
+	case KeyProductionPattern:
+		return c.Safety.ProductionPattern
+	case KeyMaxDatabaseBytes:
+		if c.Safety.MaxDatabaseBytes != nil {
+			return strconv.FormatInt(*c.Safety.MaxDatabaseBytes, 10)
+		}
+	case KeyMaxTableRows:
+		if c.Safety.MaxTableRows != nil {
+			return strconv.FormatInt(*c.Safety.MaxTableRows, 10)
+		}
+	case KeyOutput:
+		return c.Output
+	case KeyTrackingEnabled:
+		if c.Tracking.Enabled != nil {
+			return strconv.FormatBool(*c.Tracking.Enabled)
+		}
+	case KeyTrackingKeep:
+		if c.Tracking.Keep > 0 {
+			return strconv.Itoa(c.Tracking.Keep)
+		}
+	}
+	return ""
+}
+
+// Resolved is the merged configuration together with its origins.
+type Resolved struct {
+	Config
+	// Files lists the configuration files applied, lowest precedence first
+	Files []string
+	// Sources maps setting keys to where their value came from
+	// (a file path or "env NAME"). Unset keys are absent.
+	Sources map[string]string
+}
+
+// WorkerCount returns the configured worker count or DefaultWorkers.
+func (r *Resolved) WorkerCount() int {
+	if r.Workers > 0 {
+		return r.Workers
+	}
+	return DefaultWorkers
+}
+
+// OutputFormat returns the configured output format or OutputText.
+func (r *Resolved) OutputFormat() string {
+	if r.Output != "" {
+		return r.Output
+	}
+	return OutputText
+}
+
+// TrackingEnabled reports whether inserted rows are tracked; the default is true.
+func (r *Resolved) TrackingEnabled() bool {
+	return r.Tracking.Enabled == nil || *r.Tracking.Enabled
+}
+
+// TrackingKeep returns the configured tracking retention or DefaultTrackingKeep.
+func (r *Resolved) TrackingKeep() int {
+	if r.Tracking.Keep > 0 {
+		return r.Tracking.Keep
+	}
+	return DefaultTrackingKeep
+}
+
+// Load resolves the configuration for the current working directory.
+func Load() (*Resolved, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFrom(wd)
+}
+
+// LoadFrom resolves the configuration for a project rooted at or above dir.
+func LoadFrom(dir string) (*Resolved, error) {
+	r := &Resolved{Sources: map[string]string{}}
+	for _, path := range Discover(dir) {
+		c, err := ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		r.Files = append(r.Files, path)
+		r.merge(c, path)
+	}
+	env, envSources, err := fromEnv()
+	if err != nil {
+		return nil, err
+	}
+	r.merge(env, "")
+	for k, v := range envSources {
+		r.Sources[k] = v
+	}
+	if err := Validate(r.Config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Discover returns the configuration files that apply to dir, lowest precedence
+// first: the user file, then the nearest project file. Missing files are omitted.
+func Discover(dir string) []string {
+	var files []string
+	user := UserFile()
+	if user != "" {
+		files = append(files, user)
+	}
+	if project := ProjectFile(dir); project != "" && !sameFile(project, user) {
+		files = append(files, project)
+	}
+	return files
+}
+
+// UserFile returns the user configuration file, or "" if it does not exist.
+func UserFile() string {
+	base, err := userdir.Dir()
+	if err != nil {
+		return ""
+	}
+	return findIn(base)
+}
+
+// ProjectFile returns the nearest seedfast.yaml at or above dir, or "".
+func ProjectFile(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		if p := findIn(dir); p != "" {
+			return p
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func findIn(dir string) string {
+	for _, name := range FileNames {
+		p := filepath.Join(dir, name)
+		if st, err := os.Stat(p); err == nil && !st.IsDir() {
+			return p
+		}
+	}
+	return ""
+}
+
+func sameFile(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	sa, err1 := os.Stat(a)
+	sb, err2 := os.Stat(b)
+	return err1 == nil && err2 == nil && os.SameFile(sa, sb)
+}
+
+// ReadFile parses and validates a configuration file. Unknown keys are errors
+// so that typos do not silently fall back to defaults.
+func ReadFile(path string) (Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("read config: %w", err)
+	}
+	c, err := Parse(b)
+	if err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Parse decodes and validates configuration data.
+func Parse(data []byte) (Config, error) {
+	var c Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+		return Config{}, err
+	}
+	if err := Validate(c); err != nil {
+		return Config{}, err
+	}
+	return c, nil
+}
+
+// Validate reports every invalid setting in c.
+func Validate(c Config) error {
+	var errs []error
+	if c.Connection != "" {
+		if err := keychain.ValidateProfileName(c.Connection); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", KeyConnection, err))
+		}
+	}
+	if c.Workers < 0 || c.Workers > MaxWorkers {
+		errs = append(errs, fmt.Errorf("%s: must be between 1 and %d, got %d", KeyWorkers, MaxWorkers, c.Workers))
+	}
+	switch c.Output {
+	case "", OutputText, OutputPlain, OutputJSON:
+	default:
+		errs = append(errs, fmt.Errorf("%s: must be one of %s, %s or %s, got %q", KeyOutput, OutputText, OutputPlain, OutputJSON, c.Output))
+	}
+	if c.Safety.ProductionPattern != "" {
+		if _, err := regexp.Compile(c.Safety.ProductionPattern); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", KeyProductionPattern, err))
+		}
+	}
+	if v := c.Safety.MaxDatabaseBytes; v != nil && *v < 0 {
+		errs = append(errs, fmt.Errorf("%s: must not be negative", KeyMaxDatabaseBytes))
+	}
+	if v := c.Safety.MaxTableRows; v != nil && *v < 0 {
+		errs = append(errs, fmt.Errorf("%s: must not be negative", KeyMaxTableRows))
+	}
+	if c.Tracking.Keep < 0 {
+		errs = append(errs, fmt.Errorf("%s: must not be negative", KeyTrackingKeep))
+	}
+	errs = append(errs, validatePatterns("schemas", "schema", c.Schemas.Include, c.Schemas.Exclude)...)
+	errs = append(errs, validatePatterns("tables", "table", c.Tables.Include, c.Tables.Exclude)...)
+	switch c.Scale {
+	case "", ScaleSmall, ScaleMedium, ScaleLarge:
+	default:
+		errs = append(errs, fmt.Errorf("%s: must be one of %s, %s or %s, got %q", KeyScale, ScaleSmall, ScaleMedium, ScaleLarge, c.Scale))
+	}
+	if c.Locale != "" && !localeRegex.MatchString(c.Locale) {
+		errs = append(errs, fmt.Errorf("%s: must be a language tag such as en-US or de-DE, got %q", KeyLocale, c.Locale))
+	}
+	for table, n := range c.Rows {
+		if strings.TrimSpace(table) == "" {
+			errs = append(errs, fmt.Errorf("%s: table names must not be empty", KeyRows))
+		} else if n <= 0 {
+			errs = append(errs, fmt.Errorf("%s: %s must be positive, got %d", KeyRows, table, n))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validatePatterns checks include and exclude lists of names or glob patterns.
+func validatePatterns(key, kind string, include, exclude []string) []error {
+	var errs []error
+	for _, s := range append(append([]string(nil), include...), exclude...) {
+		if strings.TrimSpace(s) == "" {
+			errs = append(errs, fmt.Errorf("%s: %s names must not be empty", key, kind))
+			break
+		}
+		if _, err := path.Match(s, ""); err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid pattern %q", key, s))
+		}
+	}
+	for _, s := range include {
+		if contains(exclude, s) {
+			errs = append(errs, fmt.Errorf("%s: %q is both included and excluded", key, s))
+		}
+	}
+	return errs
+}
+
+// merge overlays the values set in c. A non-empty origin is recorded as the
+// source of every value taken from c.
+func (r *Resolved) merge(c Config, origin string) {
+	set := func(key string) {
+		if origin != "" {
+			r.Sources[key] = origin
+		}
+	}
+	if c.Connection != "" {
+		r.Connection = c.Connection
+		set(KeyConnection)
+	}
+	if c.Schemas.Include != nil {
+		r.Schemas.Include = c.Schemas.Include
+		set(KeySchemasInclude)
+	}
+	if c.Schemas.Exclude != nil {
+		r.Schemas.Exclude = c.Schemas.Exclude
+		set(KeySchemasExclude)
+	}
+	if c.Tables.Include != nil {
+		r.Tables.Include = c.Tables.Include
+		set(KeyTablesInclude)
+	}
+	if c.Tables.Exclude != nil {
+		r.Tables.Exclude = c.Tables.Exclude
+		set(KeyTablesExclude)
+	}
+	if c.Scale != "" {
+		r.Scale = c.Scale
+		set(KeyScale)
+	}
+	if c.Rows != nil {
+		r.Rows = c.Rows
+		set(KeyRows)
+	}
+	if c.Locale != "" {
+		r.Locale = c.Locale
+		set(KeyLocale)
+	}
+	if c.AskHuman.DefaultAnswer != "" {
+		r.AskHuman.DefaultAnswer = c.AskHuman.DefaultAnswer
+		set(KeyAskHumanAnswer)
+	}
+	if c.Workers != 0 {
+		r.Workers = c.Workers
+		set(KeyWorkers)
+	}
+	if c.Safety.ProductionPattern != "" {
+		r.Safety.ProductionPattern = c.Safety.ProductionPattern
+		set(KeyProductionPattern)
+	}
+	if c.Safety.MaxDatabaseBytes != nil {
+		r.Safety.MaxDatabaseBytes = c.Safety.MaxDatabaseBytes
+		set(KeyMaxDatabaseBytes)
+	}
+	if c.Safety.MaxTableRows != nil {
+		r.Safety.MaxTableRows = c.Safety.MaxTableRows
+		set(KeyMaxTableRows)
+	}
+	if c.Output != "" {
+		r.Output = c.Output
+		set(KeyOutput)
+	}
+	if c.Tracking.Enabled != nil {
+		r.Tracking.Enabled = c.Tracking.Enabled
+		set(KeyTrackingEnabled)
+	}
+	if c.Tracking.Keep != 0 {
+		r.Tracking.Keep = c.Tracking.Keep
+		set(KeyTrackingKeep)
+	}
+}
+
+// fromEnv reads the settings overridden by environment variables.
+func fromEnv() (Config, map[string]string, error) {
+	var c Config
+	sources := map[string]string{}
+	get := func(name, key string) string {
+		v := strings.TrimSpace(os.Getenv(name))
+		if v != "" {
+			sources[key] = "env " + name
+		}
+		return v
+	}
+	c.Connection = get(EnvConnection, KeyConnection)
+	if v := get(EnvSchemas, KeySchemasInclude); v != "" {
+		c.Schemas.Include = SplitList(v)
+	}
+	if v := get(EnvExcludeSchemas, KeySchemasExclude); v != "" {
+		c.Schemas.Exclude = SplitList(v)
+	}
+	if v := get(EnvTables, KeyTablesInclude); v != "" {
+		c.Tables.Include = SplitList(v)
+	}
+	if v := get(EnvExcludeTables, KeyTablesExclude); v != "" {
+		c.Tables.Exclude = SplitList(v)
+	}
+	c.Scale = strings.ToLower(get(EnvScale, KeyScale))
+	if v := get(EnvRows, KeyRows); v != "" {
+		rows, err := ParseRows(v)
+		if err != nil {
+			return c, nil, fmt.Errorf("%s: %w", EnvRows, err)
+		}
+		c.Rows = rows
+	}
+	c.Locale = get(EnvLocale, KeyLocale)
+	c.AskHuman.DefaultAnswer = get(EnvAskHumanAnswer, KeyAskHumanAnswer)
+	if v := get(EnvWorkers, KeyWorkers); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return c, nil, fmt.Errorf("%s: must be a positive integer, got %q", EnvWorkers, v)
+		}
+		c.Workers = n
+	}
+	c.Safety.ProductionPattern = get(EnvProductionPattern, KeyProductionPattern)
+	c.Output = strings.ToLower(get(EnvOutput, KeyOutput))
+	return c, sources, nil
+}
+
+// SplitList splits a comma-separated list, dropping empty items.
+func SplitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// ParseRows parses row-count targets written as "users=500,orders=5000".
+func ParseRows(s string) (map[string]int64, error) {
+	rows := map[string]int64{}
+	for _, item := range SplitList(s) {
+		table, count, ok := strings.Cut(item, "=")
+		table = strings.TrimSpace(table)
+		n, err := strconv.ParseInt(strings.TrimSpace(count), 10, 64)
+		if !ok || table == "" || err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid row target %q (use table=count with a positive count)", item)
+		}
+		rows[table] = n
+	}
+	return rows, nil
+}
+
+// FormatRows formats row-count targets as "orders=5000, users=500", sorted by table.
+func FormatRows(rows map[string]int64) string {
+	tables := make([]string, 0, len(rows))
+	for t := range rows {
+		tables = append(tables, t)
+	}
+	sort.Strings(tables)
+	items := make([]string, len(tables))
+	for i, t := range tables {
+		items[i] = fmt.Sprintf("%s=%d", t, rows[t])
+	}
+	return strings.Join(items, ", ")
+}
+
+// AllowsSchema reports whether writes to schema are permitted by the include
+// and exclude lists.
+func (s Schemas) AllowsSchema(schema string) bool {
+	if matchAny(s.Exclude, schema) {
+		return false
+	}
+	return len(s.Include) == 0 || matchAny(s.Include, schema)
+}
+
+// AllowsTable reports whether writes to table in schema are permitted by the
+// include and exclude lists.
+func (t Tables) AllowsTable(schema, table string) bool {
+	match := func(patterns []string) bool {
+		for _, p := range patterns {
+			name := table
+			if strings.Contains(p, ".") {
+				name = schema + "." + table
+			}
+			if ok, _ := path.Match(p, name); ok {
+				return true
+			}
+		}
+		return false
+	}
+	if match(t.Exclude) {
+		return false
+	}
+	return len(t.Include) == 0 || match(t.Include)
+}
+
+// matchAny reports whether name matches any of the glob patterns.
+func matchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// isolate points the user directory at a temporary location and clears the
+// environment overrides.
+func isolate(t *testing.T) string {
+	t.Helper()
+	user := t.TempDir()
+	t.Setenv("SEEDFAST_CONFIG_DIR", user)
+	for _, k := range []string{EnvConnection, EnvSchemas, EnvExcludeSchemas, EnvTables, EnvExcludeTables, EnvScale, EnvRows, EnvLocale, EnvAskHumanAnswer, EnvWorkers, EnvProductionPattern, EnvOutput} {
+		t.Setenv(k, "")
+	}
+	return user
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	user := isolate(t)
+	project := t.TempDir()
+	userFile := filepath.Join(user, "seedfast.yaml")
+	projectFile := filepath.Join(project, "seedfast.yml")
+	writeFile(t, userFile, "connection: personal\nworkers: 2\noutput: plain\nsafety:\n  max_table_rows: 10\n")
+	writeFile(t, projectFile, "connection: staging\nschemas:\n  include: [public, billing]\ntracking:\n  enabled: false\n")
+	t.Setenv(EnvWorkers, "8")
+
+	// Discovery walks up from nested directories
+	r, err := LoadFrom(filepath.Join(project, "src", "app"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r.Files, []string{userFile, projectFile}) {
+		t.Fatalf("Files = %v", r.Files)
+	}
+	if r.Connection != "staging" || r.Sources[KeyConnection] != projectFile {
+		t.Errorf("connection = %q from %q", r.Connection, r.Sources[KeyConnection])
+	}
+	if r.WorkerCount() != 8 || r.Sources[KeyWorkers] != "env "+EnvWorkers {
+		t.Errorf("workers = %d from %q", r.Workers, r.Sources[KeyWorkers])
+	}
+	if r.OutputFormat() != OutputPlain || r.Sources[KeyOutput] != userFile {
+		t.Errorf("output = %q from %q", r.Output, r.Sources[KeyOutput])
+	}
+	if r.Safety.MaxTableRows == nil || *r.Safety.MaxTableRows != 10 {
+		t.Errorf("max_table_rows = %v", r.Safety.MaxTableRows)
+	}
+	if r.TrackingEnabled() || r.TrackingKeep() != DefaultTrackingKeep || r.Sources[KeyTrackingEnabled] != projectFile {
+		t.Errorf("tracking = %+v from %q", r.Tracking, r.Sources[KeyTrackingEnabled])
+	}
+	if !r.Schemas.AllowsSchema("billing") || r.Schemas.AllowsSchema("audit") {
+		t.Errorf("schemas = %+v", r.Schemas)
+	}
+}
+
+func TestDefaultsWithoutFiles(t *testing.T) {
+	isolate(t)
+	r, err := LoadFrom(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Files) != 0 || r.WorkerCount() != DefaultWorkers || r.OutputFormat() != OutputText || !r.TrackingEnabled() {
+		t.Fatalf("unexpected defaults: %+v", r)
+	}
+}
+
+func TestParseRejectsInvalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown key":       "worker: 3\n",
+		"workers range":     "workers: 1000\n",
+		"output":            "output: xml\n",
+		"tracking keep":     "tracking:\n  keep: -1\n",
+		"pattern":           "safety:\n  production_pattern: \"(\"\n",
+		"connection name":   "connection: ../prod\n",
+		"include & exclude": "schemas:\n  include: [public]\n  exclude: [public]\n",
+		"table pattern":     "tables:\n  include: [\"billing.[\"]\n",
+		"empty table":       "tables:\n  exclude: [\"\"]\n",
+		"scale":             "scale: huge\n",
+		"row count":         "rows:\n  users: 0\n",
+		"locale":            "locale: german\n",
+	}
+	for name, data := range tests {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if _, err := Parse(nil); err != nil {
+		t.Errorf("empty file: %v", err)
+	}
+}
+
+func TestInvalidEnvironment(t *testing.T) {
+	isolate(t)
+	t.Setenv(EnvWorkers, "many")
+	if _, err := LoadFrom(t.TempDir()); err == nil {
+		t.Fatal("expected error for invalid SEEDFAST_WORKERS")
+	}
+	t.Setenv(EnvWorkers, "")
+	t.Setenv(EnvRows, "users=500,orders")
+	if _, err := LoadFrom(t.TempDir()); err == nil {
+		t.Fatal("expected error for invalid SEEDFAST_ROWS")
+	}
+}
+
+func TestRowTargets(t *testing.T) {
+	isolate(t)
+	project := t.TempDir()
+	writeFile(t, filepath.Join(project, "seedfast.yaml"), "scale: small\nlocale: de-DE\nrows:\n  users: 50\n")
+	t.Setenv(EnvRows, " users = 500, billing.invoices=2000 ")
+
+	r, err := LoadFrom(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int64{"users": 500, "billing.invoices": 2000}
+	if !reflect.DeepEqual(r.Rows, want) || r.Sources[KeyRows] != "env "+EnvRows {
+		t.Errorf("rows = %v from %q", r.Rows, r.Sources[KeyRows])
+	}
+	if r.Scale != ScaleSmall || r.Locale != "de-DE" {
+		t.Errorf("scale = %q, locale = %q", r.Scale, r.Locale)
+	}
+	if got := FormatRows(r.Rows); got != "billing.invoices=2000, users=500" {
+		t.Errorf("FormatRows = %q", got)
+	}
+}
+
+func TestScopePatterns(t *testing.T) {
+	schemas := Schemas{Include: []string{"billing", "tenant_*"}, Exclude: []string{"tenant_test"}}
+	for schema, want := range map[string]bool{"billing": true, "tenant_42": true, "tenant_test": false, "public": false} {
+		if got := schemas.AllowsSchema(schema); got != want {
+			t.Errorf("AllowsSchema(%q) = %v, want %v", schema, got, want)
+		}
+	}
+
+	tables := Tables{Include: []string{"billing.*", "users"}, Exclude: []string{"*_archive", "billing.audit_?"}}
+	tests := []struct {
+		schema, table string
+		want          bool
+	}{
+		{"billing", "invoices", true},
+		{"public", "users", true},
+		{"auth", "users", true},
+		{"public", "orders", false},
+		{"billing", "invoices_archive", false},
+		{"billing", "audit_1", false},
+		{"billing", "audit_log", true},
+	}
+	for _, tt := range tests {
+		if got := tables.AllowsTable(tt.schema, tt.table); got != tt.want {
+			t.Errorf("AllowsTable(%q, %q) = %v, want %v", tt.schema, tt.table, got, tt.want)
+		}
+	}
+	if !(Tables{}).AllowsTable("public", "users") {
+		t.Error("empty Tables must allow every table")
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+// Package diagnose inspects a database connection in detail for troubleshooting.
+//
+// Inspect reports what seeding depends on: the server version, whether the
+// connection is encrypted, the role in use and its privileges on the target
+// schemas, installed extensions and table counts. Fixes turns connection
+// failures (pg_hba rejections, SSL negotiation, password authentication,
+// unreachable hosts) into concrete next steps.
+package diagnose
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Report describes a reachable PostgreSQL database.
+type Report struct {
+	// Latency is the round-trip time of a trivial query
+	Latency time.Duration
+	// ServerVersion is the server_version setting, e.g. "16.3"
+	ServerVersion string
+	// Database is the current database name
+	Database string
+	// SSL reports whether the connection is encrypted
+	SSL bool
+	// SSLVersion and SSLCipher describe the TLS session when SSL is true
+	SSLVersion string
+	SSLCipher  string
+	// Role is the current role and Superuser whether it bypasses privilege checks
+	Role      string
+	Superuser bool
+	// Schemas lists user schemas with their privileges, in name order
+	Schemas []Schema
+	// Extensions lists installed extensions in name order
+	Extensions []Extension
+}
+
+// Schema holds the privileges of the current role on one schema.
+type Schema struct {
+	Name string
+	// Tables counts ordinary and partitioned tables
+	Tables int
+	// Usage and Create are the schema-level privileges
+	Usage  bool
+	Create bool
+	// Select and Insert count the tables the role may read and write
+	Select int
+	Insert int
+}
+
+// Extension is an installed extension.
+type Extension struct {
+	Name    string
+	Version string
+}
+
+// Warnings returns problems that would make seeding fail or be risky, each
+// with the statement or setting that resolves it.
+func (r *Report) Warnings() []string {
+	var out []string
+	if !r.SSL {
+		out = append(out, "connection is not encrypted; use sslmode=require (or verify-full) for databases outside your machine")
+	}
+	if r.Superuser {
+		return out
+	}
+	for _, s := range r.Schemas {
+		if !s.Usage {
+			out = append(out, fmt.Sprintf("role %s lacks USAGE on schema %s: GRANT USAGE ON SCHEMA %s TO %s;", r.Role, s.Name, s.Name, r.Role))
+			continue
+		}
+		if s.Insert < s.Tables {
+			out = append(out, fmt.Sprintf("role %s can insert into %d of %d tables in %s: GRANT INSERT ON ALL TABLES IN SCHEMA %s TO %s;", r.Role, s.Insert, s.Tables, s.Name, s.Name, r.Role))
+		}
+		if s.Select < s.Tables {
+			out = append(out, fmt.Sprintf("role %s can read %d of %d tables in %s: GRANT SELECT ON ALL TABLES IN SCHEMA %s TO %s;", r.Role, s.Select, s.Tables, s.Name, s.Name, r.Role))
+		}
+	}
+	return out
+}
+
+// Inspect collects a Report over an existing pool. allowSchema filters the
+// schemas to report (nil reports all user schemas). Privilege checks are
+// evaluated server-side with has_schema_privilege and has_table_privilege.
+func Inspect(ctx context.Context, pool *pgxpool.Pool, allowSchema func(string) bool) (*Report, error) {
+	r := &Report{}
+
+	start := time.Now()
+	if err := pool.QueryRow(ctx, "SELECT current_setting('server_version'), current_database(), current_user").
+		Scan(&r.ServerVersion, &r.Database, &r.Role); err != nil {
+		return nil, err
+	}
+	r.Latency = time.Since(start)
+
+	if err := pool.QueryRow(ctx, "SELECT rolsuper FROM pg_roles WHERE rolname = current_user").Scan(&r.Superuser); err != nil {
+		return nil, fmt.Errorf("read role attributes: %w", err)
+	}
+
+	// pg_stat_ssl is readable for the own backend without extra privileges
+	var version, cipher *string
+	if err := pool.QueryRow(ctx, "SELECT ssl, version, cipher FROM pg_stat_ssl WHERE pid = pg_backend_pid()").
+		Scan(&r.SSL, &version, &cipher); err != nil {
+		return nil, fmt.Errorf("read SSL status: %w", err)
+	}
+	if version != nil {
+		r.SSLVersion = *version
+	}
+	if cipher != nil {
+		r.SSLCipher = *cipher
+	}
+
+	rows, err := pool.Query(ctx, `
+		SELECT n.nspname,
+		       count(c.oid),
+		       has_schema_privilege(n.oid, 'USAGE'),
+		       has_schema_privilege(n.oid, 'CREATE'),
+		       count(c.oid) FILTER (WHERE has_schema_privilege(n.oid, 'USAGE') AND has_table_privilege(c.oid, 'SELECT')),
+		       count(c.oid) FILTER (WHERE has_schema_privilege(n.oid, 'USAGE') AND has_table_privilege(c.oid, 'INSERT'))
+		FROM pg_namespace n
+		LEFT JOIN pg_class c ON c.relnamespace = n.oid AND c.relkind IN ('r', 'p')
+		WHERE n.nspname NOT LIKE 'pg\_%' AND n.nspname <> 'information_schema'
+		GROUP BY n.oid, n.nspname
+		ORDER BY n.nspname`)
+	if err != nil {
+		return nil, fmt.Errorf("read schema privileges: %w", err)
+	}
+	for rows.Next() {
+		var s Schema
+		if err := rows.Scan(&s.Name, &s.Tables, &s.Usage, &s.Create, &s.Select, &s.Insert); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if allowSchema == nil || allowSchema(s.Name) {
+			r.Schemas = append(r.Schemas, s)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = pool.Query(ctx, "SELECT extname, extversion FROM pg_extension ORDER BY extname")
+	if err != nil {
+		return nil, fmt.Errorf("read extensions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var e Extension
+		if err := rows.Scan(&e.Name, &e.Version); err != nil {
+			return nil, err
+		}
+		r.Extensions = append(r.Extensions, e)
+	}
+	return r, rows.Err()
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package diagnose
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"seedfast/cli/internal/dsn"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Fixes suggests next steps for a failed PostgreSQL connection attempt. info
+// describes the target and may be nil. It returns nil when the failure is not
+// recognized.
+func Fixes(err error, info *dsn.DSNInfo) []string {
+	if err == nil {
+		return nil
+	}
+	host, port, user, database := "the server", "5432", "the user", "the database"
+	if info != nil {
+		if info.Host != "" {
+			host = info.Host
+		}
+		if info.Port != "" {
+			port = info.Port
+		}
+		if info.User != "" {
+			user = info.User
+		}
+		if info.Database != "" {
+			database = info.Database
+		}
+	}
+	msg := strings.ToLower(err.Error())
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == "28000" && strings.Contains(msg, "pg_hba.conf"):
+			fixes := []string{
+				fmt.Sprintf("the server has no pg_hba.conf rule for user %s, database %s from this machine", user, database),
+				fmt.Sprintf("add a line such as 'hostssl %s %s <your-ip>/32 scram-sha-256' to pg_hba.conf and reload the server (SELECT pg_reload_conf())", database, user),
+			}
+			if strings.Contains(msg, "no encryption") {
+				fixes = append(fixes, "the existing rules may require SSL: add sslmode=require to the connection string")
+			}
+			return fixes
+		case pgErr.Code == "28P01" || pgErr.Code == "28000":
+			return []string{
+				fmt.Sprintf("password authentication failed for user %s: check the password in the connection string", user),
+				"passwords with special characters must be percent-encoded in URLs (e.g. @ as %40), or use a keyword/value string",
+				"a password from ~/.pgpass or PGPASSWORD is only used when the connection string has none",
+			}
+		case pgErr.Code == "3D000":
+			return []string{fmt.Sprintf("database %s does not exist: create it (CREATE DATABASE %s) or fix the name in the connection string", database, database)}
+		case pgErr.Code == "42501":
+			return []string{fmt.Sprintf("user %s may not connect: GRANT CONNECT ON DATABASE %s TO %s;", user, database, user)}
+		case pgErr.Code == "53300":
+			return []string{"the server has no free connection slots: close idle sessions or raise max_connections"}
+		}
+	}
+
+	switch {
+	case strings.Contains(msg, "server refused tls") || strings.Contains(msg, "server does not support ssl"):
+		return []string{"the server does not accept SSL connections: use sslmode=disable (or prefer) for local servers, or enable ssl in postgresql.conf"}
+	case strings.Contains(msg, "certificate") || strings.Contains(msg, "x509"):
+		return []string{
+			"the server certificate could not be verified",
+			"set sslrootcert=/path/to/ca.pem with the CA that signed it, or use sslmode=require to encrypt without verification",
+		}
+	case strings.Contains(msg, "ssl") && strings.Contains(msg, "required"):
+		return []string{"the server requires SSL: add sslmode=require to the connection string"}
+	case strings.Contains(msg, "connection refused"):
+		return []string{
+			fmt.Sprintf("nothing is listening on %s:%s: check that PostgreSQL is running and the port is correct", host, port),
+			"for remote servers, listen_addresses in postgresql.conf must include the interface you connect to",
+		}
+	case strings.Contains(msg, "no such host") || isDNSError(err):
+		return []string{fmt.Sprintf("host %s could not be resolved: check the host name and your DNS or VPN", host)}
+	case strings.Contains(msg, "timeout") || strings.Contains(msg, "deadline exceeded"):
+		return []string{fmt.Sprintf("%s:%s did not answer in time: a firewall, security group or VPN may be blocking the port", host, port)}
+	case strings.Contains(msg, "no such file or directory") && info != nil && dsn.IsUnixSocket(info.Host):
+		return []string{fmt.Sprintf("no server socket in %s: check that PostgreSQL is running locally and unix_socket_directories", info.Host)}
+	}
+	return nil
+}
+
+func isDNSError(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
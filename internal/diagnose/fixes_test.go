@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package diagnose
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"seedfast/cli/internal/dsn"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestFixes(t *testing.T) {
+	info := &dsn.DSNInfo{Host: "db.internal", Port: "5432", User: "app", Database: "shop"}
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "pg_hba",
+			err:  fmt.Errorf("failed to connect: %w", &pgconn.PgError{Code: "28000", Message: `no pg_hba.conf entry for host "10.0.0.5", user "app", database "shop", no encryption`}),
+			want: "sslmode=require",
+		},
+		{
+			name: "password",
+			err:  &pgconn.PgError{Code: "28P01", Message: `password authentication failed for user "app"`},
+			want: "percent-encoded",
+		},
+		{
+			name: "missing database",
+			err:  &pgconn.PgError{Code: "3D000", Message: `database "shop" does not exist`},
+			want: "CREATE DATABASE shop",
+		},
+		{
+			name: "ssl unsupported",
+			err:  errors.New("failed to connect to `host=db.internal user=app database=shop`: server refused TLS connection"),
+			want: "sslmode=disable",
+		},
+		{
+			name: "refused",
+			err:  errors.New("dial tcp 10.0.0.5:5432: connect: connection refused"),
+			want: "db.internal:5432",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixes := Fixes(tt.err, info)
+			if !strings.Contains(strings.Join(fixes, "\n"), tt.want) {
+				t.Fatalf("Fixes() = %q, want mention of %q", fixes, tt.want)
+			}
+		})
+	}
+	if fixes := Fixes(errors.New("something unexpected"), nil); fixes != nil {
+		t.Fatalf("unrecognized error: %q", fixes)
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	r := &Report{
+		Role: "app",
+		SSL:  true,
+		Schemas: []Schema{
+			{Name: "public", Tables: 3, Usage: true, Select: 3, Insert: 1},
+			{Name: "billing", Tables: 2},
+		},
+	}
+	got := strings.Join(r.Warnings(), "\n")
+	for _, want := range []string{"GRANT INSERT ON ALL TABLES IN SCHEMA public TO app", "GRANT USAGE ON SCHEMA billing TO app"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Warnings() missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "SELECT ON ALL TABLES IN SCHEMA public") {
+		t.Errorf("unexpected SELECT warning:\n%s", got)
+	}
+	r.Superuser, r.SSL = true, false
+	if w := r.Warnings(); len(w) != 1 || !strings.Contains(w[0], "not encrypted") {
+		t.Errorf("superuser Warnings() = %q", w)
+	}
+}
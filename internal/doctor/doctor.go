@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+// Package doctor runs a sequence of independent health checks and collects
+// their outcomes into a report that can be rendered as a table or attached to
+// a support ticket as JSON.
+//
+// Checks may depend on earlier ones (for example the gRPC handshake needs the
+// manifest); a check whose dependency did not pass is skipped rather than
+// failing a second time, so the report points at the first broken link.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip"
+)
+
+// Result is the outcome of a single check.
+type Result struct {
+	Name     string `json:"name"`
+	Status   Status `json:"status"`
+	Detail   string `json:"detail"`
+	Duration int64  `json:"duration_ms"`
+}
+
+// Check is a named health check. Run returns the status and a one-line detail.
+// Requires names the checks that must pass (or warn) before this one runs.
+type Check struct {
+	Name     string
+	Requires []string
+	Run      func(ctx context.Context) (Status, string)
+}
+
+// Report collects the results of a doctor run.
+type Report struct {
+	CLIVersion string    `json:"cli_version"`
+	OS         string    `json:"os"`
+	Arch       string    `json:"arch"`
+	StartedAt  time.Time `json:"started_at"`
+	Results    []Result  `json:"checks"`
+}
+
+// Failed returns the number of failed checks.
+func (r *Report) Failed() int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Status == StatusFail {
+			n++
+		}
+	}
+	return n
+}
+
+// Run executes checks in order. Each check gets its own timeout, so a hanging
+// network call cannot stall the remaining checks.
+func Run(ctx context.Context, cliVersion string, timeout time.Duration, checks []Check) *Report {
+	r := &Report{
+		CLIVersion: cliVersion,
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		StartedAt:  time.Now().UTC(),
+		Results:    make([]Result, 0, len(checks)),
+	}
+	passed := make(map[string]bool, len(checks))
+	for _, c := range checks {
+		res := Result{Name: c.Name}
+		for _, dep := range c.Requires {
+			if !passed[dep] {
+				res.Status, res.Detail = StatusSkip, fmt.Sprintf("requires %s", dep)
+				break
+			}
+		}
+		if res.Status == "" {
+			start := time.Now()
+			cctx, cancel := context.WithTimeout(ctx, timeout)
+			res.Status, res.Detail = c.Run(cctx)
+			cancel()
+			res.Duration = time.Since(start).Milliseconds()
+		}
+		passed[c.Name] = res.Status == StatusPass || res.Status == StatusWarn
+		r.Results = append(r.Results, res)
+	}
+	return r
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package doctor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunSkipsDependents(t *testing.T) {
+	ran := map[string]bool{}
+	check := func(name string, status Status, requires ...string) Check {
+		return Check{Name: name, Requires: requires, Run: func(ctx context.Context) (Status, string) {
+			ran[name] = true
+			if _, ok := ctx.Deadline(); !ok {
+				t.Errorf("%s: no timeout", name)
+			}
+			return status, name
+		}}
+	}
+	r := Run(context.Background(), "1.2.3", time.Second, []Check{
+		check("keychain", StatusWarn),
+		check("manifest", StatusFail),
+		check("grpc", StatusPass, "manifest"),
+		check("auth", StatusPass, "keychain"),
+	})
+
+	got := make([]string, len(r.Results))
+	for i, res := range r.Results {
+		got[i] = res.Name + "=" + string(res.Status)
+	}
+	if strings.Join(got, " ") != "keychain=warn manifest=fail grpc=skip auth=pass" {
+		t.Fatalf("results: %v", got)
+	}
+	if ran["grpc"] || r.Results[2].Detail != "requires manifest" {
+		t.Fatalf("grpc should be skipped: %+v", r.Results[2])
+	}
+	if r.Failed() != 1 || r.CLIVersion != "1.2.3" {
+		t.Fatalf("report: %+v", r)
+	}
+}
+
+func TestProbeTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(nil)
+	defer srv.Close()
+	addr := srv.Listener.Addr().String()
+
+	// The test certificate is not trusted by the system roots
+	if _, err := ProbeTLS(context.Background(), addr, nil); err == nil {
+		t.Fatal("untrusted certificate accepted")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	info, err := ProbeTLS(context.Background(), addr, &tls.Config{RootCAs: pool, ServerName: "example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(info.Version, "TLS 1.") || !info.CertExpiry.Equal(srv.Certificate().NotAfter) {
+		t.Fatalf("ProbeTLS() = %+v", info)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package doctor
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+)
+
+// TLSInfo describes a completed TLS handshake.
+type TLSInfo struct {
+	// Version is the negotiated protocol, e.g. "TLS 1.3"
+	Version string
+	// CertExpiry is the NotAfter of the leaf certificate
+	CertExpiry time.Time
+	// Handshake is the time to connect and complete the handshake
+	Handshake time.Duration
+}
+
+// ProbeTLS connects to addr (host:port, port 443 when missing) and completes a
+// TLS handshake with the same settings the gRPC bridge uses, verifying the
+// certificate against the system roots unless config overrides them.
+func ProbeTLS(ctx context.Context, addr string, config *tls.Config) (*TLSInfo, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+		addr = net.JoinHostPort(addr, "443")
+	}
+	cfg := &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+	if config != nil {
+		cfg = config.Clone()
+		if cfg.ServerName == "" {
+			cfg.ServerName = host
+		}
+	}
+
+	start := time.Now()
+	dialer := &tls.Dialer{Config: cfg}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	info := &TLSInfo{Version: tls.VersionName(state.Version), Handshake: time.Since(start)}
+	if len(state.PeerCertificates) > 0 {
+		info.CertExpiry = state.PeerCertificates[0].NotAfter
+	}
+	return info, nil
+}
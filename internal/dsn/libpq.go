@@ -0,0 +1,379 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package dsn
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// keywordValueRegex matches the start of a libpq keyword/value connection string,
+// e.g. "host=localhost dbname=app".
+var keywordValueRegex = regexp.MustCompile(`^\s*[A-Za-z_]+\s*=`)
+
+// IsKeywordValue reports whether dsn is a libpq keyword/value connection string
+// rather than a URL.
+func IsKeywordValue(dsn string) bool {
+	return !strings.Contains(dsn, "://") && keywordValueRegex.MatchString(dsn)
+}
+
+// parseKeywordValue splits a keyword/value connection string into its settings.
+// Values may be single-quoted; inside quotes, \' and \\ are escapes.
+func parseKeywordValue(dsn string) (map[string]string, error) {
+	settings := map[string]string{}
+	s := dsn
+	for {
+		s = strings.TrimLeft(s, " \t\n\r")
+		if s == "" {
+			return settings, nil
+		}
+		eq := strings.IndexByte(s, '=')
+		if eq <= 0 {
+			return nil, NewParseError(dsn, "missing \"=\" after keyword", "use key=value pairs separated by spaces, e.g. host=localhost dbname=app")
+		}
+		key := strings.TrimSpace(s[:eq])
+		if strings.ContainsAny(key, " \t'") {
+			return nil, NewParseError(dsn, "invalid keyword "+key, "use key=value pairs separated by spaces")
+		}
+		s = strings.TrimLeft(s[eq+1:], " \t")
+
+		var value strings.Builder
+		if strings.HasPrefix(s, "'") {
+			i, closed := 1, false
+			for i < len(s) {
+				c := s[i]
+				if c == '\\' && i+1 < len(s) {
+					value.WriteByte(s[i+1])
+					i += 2
+					continue
+				}
+				if c == '\'' {
+					closed = true
+					i++
+					break
+				}
+				value.WriteByte(c)
+				i++
+			}
+			if !closed {
+				return nil, NewParseError(dsn, "unterminated quoted value for "+key, "close the value with a single quote")
+			}
+			s = s[i:]
+		} else {
+			i := 0
+			for i < len(s) && s[i] != ' ' && s[i] != '\t' && s[i] != '\n' && s[i] != '\r' {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+				}
+				value.WriteByte(s[i])
+				i++
+			}
+			s = s[i:]
+		}
+		settings[key] = value.String()
+	}
+}
+
+// applyKeyword stores a libpq keyword in info, keeping unknown keywords as parameters.
+// Values already present in info are not overwritten.
+func applyKeyword(info *DSNInfo, key, value string) {
+	setIfEmpty := func(field *string) {
+		if *field == "" {
+			*field = value
+		}
+	}
+	switch key {
+	case "host":
+		setIfEmpty(&info.Host)
+	case "hostaddr":
+		// Only used when no host name is given; TLS verification needs the name
+		setIfEmpty(&info.Host)
+	case "port":
+		setIfEmpty(&info.Port)
+	case "user":
+		setIfEmpty(&info.User)
+	case "password":
+		setIfEmpty(&info.Password)
+	case "dbname":
+		setIfEmpty(&info.Database)
+	case "service":
+		// Resolved by applyDefaults
+	default:
+		if _, ok := info.Params[key]; !ok {
+			info.Params[key] = value
+		}
+	}
+}
+
+// envDefaults maps libpq environment variables to the keywords they provide.
+var envDefaults = []struct{ env, key string }{
+	{"PGHOST", "host"},
+	{"PGHOSTADDR", "hostaddr"},
+	{"PGPORT", "port"},
+	{"PGUSER", "user"},
+	{"PGPASSWORD", "password"},
+	{"PGDATABASE", "dbname"},
+	{"PGSSLMODE", "sslmode"},
+	{"PGAPPNAME", "application_name"},
+	{"PGCONNECT_TIMEOUT", "connect_timeout"},
+}
+
+// applyDefaults fills the settings a connection string left out, the way libpq
+// does: first from the service file entry named by service= or PGSERVICE, then
+// from the PG* environment variables, and finally the password from the
+// password file.
+func applyDefaults(info *DSNInfo, service string) error {
+	if service == "" {
+		service = os.Getenv("PGSERVICE")
+	}
+	if service != "" {
+		settings, err := lookupService(service)
+		if err != nil {
+			return NewParseError(info.Original, err.Error(), "define the service in ~/.pg_service.conf or point PGSERVICEFILE at your service file")
+		}
+		for key, value := range settings {
+			applyKeyword(info, key, value)
+		}
+	}
+	for _, d := range envDefaults {
+		if v := os.Getenv(d.env); v != "" {
+			applyKeyword(info, d.key, v)
+		}
+	}
+	if info.Port == "" {
+		info.Port = "5432"
+	}
+	if info.User == "" {
+		info.User = osUserName()
+	}
+	if info.Database == "" {
+		info.Database = info.User
+	}
+	if info.Host == "" {
+		info.Host = defaultHost(info.Port)
+	}
+	if info.Password == "" {
+		info.Password = lookupPassword(info.Host, info.Port, info.Database, info.User)
+	}
+	return nil
+}
+
+// osUserName returns the name of the current operating system user, which
+// libpq uses when no user is given.
+func osUserName() string {
+	u, err := user.Current()
+	if err != nil {
+		return ""
+	}
+	name := u.Username
+	if i := strings.LastIndex(name, `\`); i >= 0 {
+		// Windows reports DOMAIN\user
+		name = name[i+1:]
+	}
+	return name
+}
+
+// socketDirs are the usual locations of the PostgreSQL unix-domain socket.
+var socketDirs = []string{"/var/run/postgresql", "/private/tmp", "/tmp"}
+
+// defaultHost returns the socket directory of a local server listening on port,
+// or "localhost" when there is none.
+func defaultHost(port string) string {
+	if runtime.GOOS != "windows" {
+		for _, dir := range socketDirs {
+			if _, err := os.Stat(filepath.Join(dir, ".s.PGSQL."+port)); err == nil {
+				return dir
+			}
+		}
+	}
+	return "localhost"
+}
+
+// IsUnixSocket reports whether host names a unix-domain socket directory.
+func IsUnixSocket(host string) bool {
+	return strings.HasPrefix(host, "/") || strings.HasPrefix(host, "@")
+}
+
+// serviceFiles returns the service files to search, most specific first.
+func serviceFiles() []string {
+	var files []string
+	if f := os.Getenv("PGSERVICEFILE"); f != "" {
+		files = append(files, f)
+	} else if home, err := os.UserHomeDir(); err == nil {
+		files = append(files, filepath.Join(home, ".pg_service.conf"))
+	}
+	if d := os.Getenv("PGSYSCONFDIR"); d != "" {
+		files = append(files, filepath.Join(d, "pg_service.conf"))
+	} else if runtime.GOOS != "windows" {
+		files = append(files, "/etc/postgresql-common/pg_service.conf", "/etc/pg_service.conf")
+	}
+	return files
+}
+
+// lookupService returns the settings of the named service from the first
+// service file that defines it.
+func lookupService(name string) (map[string]string, error) {
+	for _, path := range serviceFiles() {
+		settings, found, err := readServiceFile(path, name)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return settings, nil
+		}
+	}
+	return nil, fmt.Errorf("service %q not found", name)
+}
+
+// readServiceFile reads the [name] section of an INI-style pg_service.conf.
+// A missing file is not an error.
+func readServiceFile(path, name string) (map[string]string, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	defer f.Close()
+
+	settings := map[string]string{}
+	found, inSection := false, false
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			inSection = strings.TrimSpace(line[1:len(line)-1]) == name
+			found = found || inSection
+			continue
+		}
+		if !inSection {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); ok {
+			settings[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, false, err
+	}
+	return settings, found, nil
+}
+
+// passwordFile returns the path of the libpq password file.
+func passwordFile() string {
+	if f := os.Getenv("PGPASSFILE"); f != "" {
+		return f
+	}
+	if runtime.GOOS == "windows" {
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "postgresql", "pgpass.conf")
+		}
+		return ""
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".pgpass")
+}
+
+// lookupPassword returns the password for the connection from the password
+// file (~/.pgpass), or "" when there is no matching entry. As with libpq, the
+// file is ignored on Unix if it is readable by group or others, and socket
+// connections match the host name "localhost".
+func lookupPassword(host, port, database, username string) string {
+	path := passwordFile()
+	if path == "" {
+		return ""
+	}
+	st, err := os.Stat(path)
+	if err != nil || !st.Mode().IsRegular() {
+		return ""
+	}
+	if runtime.GOOS != "windows" && st.Mode().Perm()&0o077 != 0 {
+		return ""
+	}
+	if host == "" || IsUnixSocket(host) {
+		host = "localhost"
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	want := []string{host, port, database, username}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		fields := splitPgpassLine(line)
+		if len(fields) != 5 {
+			continue
+		}
+		match := true
+		for i, w := range want {
+			if fields[i] != "*" && fields[i] != w {
+				match = false
+				break
+			}
+		}
+		if match {
+			return fields[4]
+		}
+	}
+	return ""
+}
+
+// splitPgpassLine splits a password file line on unescaped colons and removes
+// the backslash escapes.
+func splitPgpassLine(line string) []string {
+	var fields []string
+	var cur strings.Builder
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line):
+			i++
+			cur.WriteByte(line[i])
+		case c == ':' && len(fields) < 4:
+			fields = append(fields, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	return append(fields, cur.String())
+}
+
+// EnvironmentDSN returns a keyword/value connection string built from the
+// non-secret libpq environment variables (PGSERVICE, PGHOST, PGPORT, PGUSER,
+// PGDATABASE), or "" when none of PGSERVICE, PGHOST and PGDATABASE is set.
+// The password is resolved when the string is parsed.
+func EnvironmentDSN() string {
+	if os.Getenv("PGSERVICE") == "" && os.Getenv("PGHOST") == "" && os.Getenv("PGDATABASE") == "" {
+		return ""
+	}
+	var parts []string
+	for _, kv := range []struct{ env, key string }{
+		{"PGSERVICE", "service"}, {"PGHOST", "host"}, {"PGPORT", "port"}, {"PGUSER", "user"}, {"PGDATABASE", "dbname"},
+	} {
+		if v := os.Getenv(kv.env); v != "" {
+			parts = append(parts, kv.key+"='"+strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v)+"'")
+		}
+	}
+	return strings.Join(parts, " ")
+}
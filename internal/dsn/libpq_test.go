@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package dsn
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// isolateLibpq clears the libpq environment and points the service and
+// password files at a temporary directory.
+func isolateLibpq(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, d := range envDefaults {
+		t.Setenv(d.env, "")
+	}
+	t.Setenv("PGSERVICE", "")
+	t.Setenv("HOME", dir)
+	t.Setenv("PGSYSCONFDIR", dir)
+	t.Setenv("PGSERVICEFILE", filepath.Join(dir, "pg_service.conf"))
+	t.Setenv("PGPASSFILE", filepath.Join(dir, "pgpass"))
+	return dir
+}
+
+func TestKeywordValueDSN(t *testing.T) {
+	isolateLibpq(t)
+	if DetectDBType("host=db.internal dbname=app") != DBTypePostgreSQL {
+		t.Fatal("keyword/value string not detected as PostgreSQL")
+	}
+	info, err := ParseInfo(`host=db.internal port=6432 user=app password='p@ss \'q\' w0rd' dbname=shop sslmode=require`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Host != "db.internal" || info.Port != "6432" || info.User != "app" || info.Database != "shop" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+	if info.Password != "p@ss 'q' w0rd" || info.Params["sslmode"] != "require" {
+		t.Fatalf("password = %q, params = %v", info.Password, info.Params)
+	}
+
+	got, err := Parse(`host=db.internal user=app password=x dbname=shop sslmode=require application_name=seedfast`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "postgresql://app:x@db.internal:5432/shop?application_name=seedfast&sslmode=require"
+	if got != want {
+		t.Fatalf("Parse() = %q, want %q", got, want)
+	}
+}
+
+func TestEnvironmentFallback(t *testing.T) {
+	isolateLibpq(t)
+	t.Setenv("PGHOST", "env-host")
+	t.Setenv("PGPORT", "6543")
+	t.Setenv("PGUSER", "env-user")
+	t.Setenv("PGPASSWORD", "env-pass")
+
+	info, err := ParseInfo("dbname=app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Host != "env-host" || info.Port != "6543" || info.User != "env-user" || info.Password != "env-pass" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+	// Values in the connection string win over the environment
+	info, err = ParseInfo("postgres://url-user@url-host/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Host != "url-host" || info.User != "url-user" || info.Port != "6543" || info.Password != "env-pass" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+
+	t.Setenv("PGDATABASE", "envdb")
+	if got := EnvironmentDSN(); got != "host='env-host' port='6543' user='env-user' dbname='envdb'" {
+		t.Fatalf("EnvironmentDSN() = %q", got)
+	}
+}
+
+func TestServiceFileAndPgpass(t *testing.T) {
+	dir := isolateLibpq(t)
+	service := "# comment\n[other]\nhost=wrong\n\n[staging]\nhost = staging.internal\nport=5433\nuser=svc\ndbname=stage\nsslmode=verify-full\n"
+	if err := os.WriteFile(filepath.Join(dir, "pg_service.conf"), []byte(service), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pgpass := "# host:port:db:user:password\nother:*:*:*:nope\nstaging.internal:5433:*:svc:s\\:ecret\n*:*:*:*:fallback\n"
+	if err := os.WriteFile(filepath.Join(dir, "pgpass"), []byte(pgpass), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := ParseInfo("service=staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Host != "staging.internal" || info.Port != "5433" || info.User != "svc" || info.Database != "stage" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+	if info.Password != "s:ecret" || info.Params["sslmode"] != "verify-full" {
+		t.Fatalf("password = %q, params = %v", info.Password, info.Params)
+	}
+
+	// The connection string overrides the service; PGSERVICE names it implicitly
+	t.Setenv("PGSERVICE", "staging")
+	info, err = ParseInfo("dbname=other")
+	if err != nil || info.Database != "other" || info.Host != "staging.internal" {
+		t.Fatalf("PGSERVICE: %+v, %v", info, err)
+	}
+
+	if _, err := ParseInfo("service=missing"); err == nil {
+		t.Fatal("expected error for unknown service")
+	}
+
+	// A password file readable by others is ignored
+	if runtime.GOOS != "windows" {
+		if err := os.Chmod(filepath.Join(dir, "pgpass"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if pw := lookupPassword("staging.internal", "5433", "stage", "svc"); pw != "" {
+			t.Fatalf("insecure password file used: %q", pw)
+		}
+	}
+}
+
+func TestUnixSocketHost(t *testing.T) {
+	isolateLibpq(t)
+	for _, raw := range []string{
+		"host=/var/run/postgresql user=app dbname=shop",
+		"postgresql://app@/shop?host=/var/run/postgresql",
+	} {
+		got, err := Parse(raw)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", raw, err)
+		}
+		want := "postgresql://app@/shop?host=%2Fvar%2Frun%2Fpostgresql&port=5432"
+		if got != want {
+			t.Fatalf("Parse(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
+
+func TestParseKeywordValueErrors(t *testing.T) {
+	for _, raw := range []string{"host=a dbname", "host='unterminated"} {
+		if _, err := parseKeywordValue(raw); err == nil {
+			t.Errorf("parseKeywordValue(%q): expected error", raw)
+		}
+	}
+}
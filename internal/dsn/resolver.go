@@ -17,9 +17,16 @@ func DetectDBType(dsn string) DBType {
 	if strings.HasPrefix(lower, "mysql://") {
 		return DBTypeMySQL
 	}
+	if strings.HasPrefix(lower, "sqlite://") {
+		return DBTypeSQLite
+	}
 	if strings.HasPrefix(lower, "oracle://") {
 		return DBTypeOracle
 	}
+	// libpq keyword/value strings ("host=localhost dbname=app") are PostgreSQL-only
+	if IsKeywordValue(dsn) {
+		return DBTypePostgreSQL
+	}
 
 	return DBTypeUnknown
 }
@@ -38,11 +45,13 @@ func Parse(dsn string) (string, error) {
 	case DBTypePostgreSQL:
 		resolver = NewPostgreSQLResolver()
 	case DBTypeMySQL:
-		return "", NewParseError(dsn, "MySQL support not yet implemented", "use PostgreSQL for now")
+		resolver = NewMySQLResolver()
+	case DBTypeSQLite:
+		resolver = NewSQLiteResolver()
 	case DBTypeOracle:
-		return "", NewParseError(dsn, "Oracle support not yet implemented", "use PostgreSQL for now")
+		return "", NewParseError(dsn, "Oracle support not yet implemented", "use PostgreSQL, MySQL or SQLite for now")
 	default:
-		return "", NewParseError(dsn, "unknown database type", "use postgres://, mysql://, or oracle://")
+		return "", NewParseError(dsn, "unknown database type", "use postgres://, mysql://, sqlite://, oracle://, or host=... dbname=...")
 	}
 
 	info, err := resolver.Parse(dsn)
@@ -71,11 +80,13 @@ func Validate(dsn string) error {
 	case DBTypePostgreSQL:
 		resolver = NewPostgreSQLResolver()
 	case DBTypeMySQL:
-		return NewParseError(dsn, "MySQL support not yet implemented", "use PostgreSQL for now")
+		resolver = NewMySQLResolver()
+	case DBTypeSQLite:
+		resolver = NewSQLiteResolver()
 	case DBTypeOracle:
-		return NewParseError(dsn, "Oracle support not yet implemented", "use PostgreSQL for now")
+		return NewParseError(dsn, "Oracle support not yet implemented", "use PostgreSQL, MySQL or SQLite for now")
 	default:
-		return NewParseError(dsn, "unknown database type", "use postgres://, mysql://, or oracle://")
+		return NewParseError(dsn, "unknown database type", "use postgres://, mysql://, sqlite://, oracle://, or host=... dbname=...")
 	}
 
 	return resolver.Validate(dsn)
@@ -95,11 +106,13 @@ func ParseInfo(dsn string) (*DSNInfo, error) {
 	case DBTypePostgreSQL:
 		resolver = NewPostgreSQLResolver()
 	case DBTypeMySQL:
-		return nil, NewParseError(dsn, "MySQL support not yet implemented", "use PostgreSQL for now")
+		resolver = NewMySQLResolver()
+	case DBTypeSQLite:
+		resolver = NewSQLiteResolver()
 	case DBTypeOracle:
-		return nil, NewParseError(dsn, "Oracle support not yet implemented", "use PostgreSQL for now")
+		return nil, NewParseError(dsn, "Oracle support not yet implemented", "use PostgreSQL, MySQL or SQLite for now")
 	default:
-		return nil, NewParseError(dsn, "unknown database type", "use postgres://, mysql://, or oracle://")
+		return nil, NewParseError(dsn, "unknown database type", "use postgres://, mysql://, sqlite://, oracle://, or host=... dbname=...")
 	}
 
 	return resolver.Parse(dsn)
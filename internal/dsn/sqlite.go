@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package dsn
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// SQLiteResolver handles SQLite DSN parsing and normalization.
+// DSNs name a database file: sqlite:///absolute/path/dev.db or sqlite://./dev.db
+// for a path relative to the working directory, which normalization makes
+// absolute. Query parameters are passed to the SQLite driver.
+type SQLiteResolver struct{}
+
+// NewSQLiteResolver creates a new SQLite resolver
+func NewSQLiteResolver() *SQLiteResolver {
+	return &SQLiteResolver{}
+}
+
+// Parse parses a SQLite DSN string and returns DSN info. The file path is
+// stored in Database; Host, Port and credentials are always empty.
+func (r *SQLiteResolver) Parse(dsn string) (*DSNInfo, error) {
+	if dsn == "" {
+		return nil, NewParseError(dsn, "empty DSN", "provide a valid SQLite connection string")
+	}
+	if !strings.HasPrefix(strings.ToLower(dsn), "sqlite://") {
+		return nil, NewParseError(dsn, "missing or invalid scheme", "use sqlite://")
+	}
+	path, paramStr, _ := strings.Cut(dsn[len("sqlite://"):], "?")
+
+	info := &DSNInfo{
+		Type:     DBTypeSQLite,
+		Database: unescape(path),
+		Params:   make(map[string]string),
+		Original: dsn,
+	}
+	if paramStr != "" {
+		values, err := url.ParseQuery(paramStr)
+		if err != nil {
+			return nil, NewParseError(dsn, "invalid query parameters", err.Error())
+		}
+		for key, v := range values {
+			if len(v) > 0 {
+				info.Params[key] = v[0]
+			}
+		}
+	}
+
+	if strings.TrimSpace(info.Database) == "" {
+		return nil, NewParseError(dsn, "missing database file", "format should be sqlite:///path/to/dev.db or sqlite://./dev.db")
+	}
+	if info.Database == ":memory:" {
+		return nil, NewParseError(dsn, "in-memory databases cannot be seeded", "point sqlite:// at a database file")
+	}
+	return info, nil
+}
+
+// Normalize converts DSN info to a sqlite:// URL with an absolute file path,
+// so a saved DSN names the same file from any working directory.
+func (r *SQLiteResolver) Normalize(info *DSNInfo) (string, error) {
+	if info == nil {
+		return "", NewParseError("", "nil DSN info", "")
+	}
+	file, err := filepath.Abs(info.Database)
+	if err != nil {
+		return "", NewParseError(info.Original, "cannot resolve database file path", err.Error())
+	}
+	var builder strings.Builder
+	builder.WriteString("sqlite://")
+	// Keep path separators readable; only characters that would end the path are escaped
+	builder.WriteString(strings.NewReplacer("%", "%25", "?", "%3F", "#", "%23").Replace(file))
+	writeParams(&builder, info.Params)
+	return builder.String(), nil
+}
+
+// Validate checks if the DSN is valid for SQLite
+func (r *SQLiteResolver) Validate(dsn string) error {
+	_, err := r.Parse(dsn)
+	return err
+}
+
+// SQLiteFile returns the database file path of a sqlite:// DSN.
+func SQLiteFile(dsn string) (string, error) {
+	info, err := NewSQLiteResolver().Parse(dsn)
+	if err != nil {
+		return "", err
+	}
+	return info.Database, nil
+}
+
+// SQLiteDriverDSN converts a sqlite:// URL into the file: URI accepted by the
+// SQLite driver. Foreign keys are enforced and writers wait for locks, unless
+// the DSN sets its own pragmas.
+func SQLiteDriverDSN(dsn string) (string, error) {
+	info, err := NewSQLiteResolver().Parse(dsn)
+	if err != nil {
+		return "", err
+	}
+	var builder strings.Builder
+	builder.WriteString("file:")
+	builder.WriteString(strings.NewReplacer("%", "%25", "?", "%3F", "#", "%23").Replace(info.Database))
+
+	params := url.Values{}
+	for k, v := range info.Params {
+		params.Set(k, v)
+	}
+	if _, ok := params["_pragma"]; !ok {
+		params["_pragma"] = []string{"foreign_keys(1)", "busy_timeout(5000)"}
+	}
+	builder.WriteString("?")
+	builder.WriteString(params.Encode())
+	return builder.String(), nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package dsn
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSQLiteParse(t *testing.T) {
+	// Relative paths are made absolute against the working directory
+	wd := t.TempDir()
+	t.Chdir(wd)
+	dev := filepath.Join(wd, "dev.db")
+	tests := []struct {
+		raw    string
+		file   string
+		driver string
+	}{
+		{"sqlite:///var/data/dev.db", "/var/data/dev.db", "file:/var/data/dev.db?_pragma=foreign_keys%281%29&_pragma=busy_timeout%285000%29"},
+		{"sqlite://./dev.db", dev, "file:" + dev + "?_pragma=foreign_keys%281%29&_pragma=busy_timeout%285000%29"},
+		{"SQLITE://dev.db?_pragma=journal_mode(WAL)", dev, "file:" + dev + "?_pragma=journal_mode%28WAL%29"},
+	}
+	for _, tt := range tests {
+		if DetectDBType(tt.raw) != DBTypeSQLite {
+			t.Errorf("DetectDBType(%q) = %q", tt.raw, DetectDBType(tt.raw))
+		}
+		normalized, err := Parse(tt.raw)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.raw, err)
+		}
+		file, err := SQLiteFile(normalized)
+		if err != nil || file != tt.file {
+			t.Errorf("SQLiteFile(%q) = %q, %v; want %q", normalized, file, err, tt.file)
+		}
+		driver, err := SQLiteDriverDSN(normalized)
+		if err != nil || driver != tt.driver {
+			t.Errorf("SQLiteDriverDSN(%q) = %q, %v; want %q", normalized, driver, err, tt.driver)
+		}
+	}
+
+	for _, bad := range []string{"sqlite://", "sqlite://:memory:"} {
+		if err := Validate(bad); err == nil {
+			t.Errorf("Validate(%q): expected error", bad)
+		}
+	}
+}
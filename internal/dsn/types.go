@@ -11,6 +11,7 @@ type DBType string
 const (
 	DBTypePostgreSQL DBType = "postgresql"
 	DBTypeMySQL      DBType = "mysql"
+	DBTypeSQLite     DBType = "sqlite"
 	DBTypeOracle     DBType = "oracle"
 	DBTypeUnknown    DBType = "unknown"
 )
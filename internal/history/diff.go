@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package history
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Change is one difference between two session records.
+type Change struct {
+	// Field names what differs: a session attribute, a table or an answer
+	Field string
+	Old   string
+	New   string
+}
+
+// Diff compares two session records and lists what changed from a to b:
+// session attributes, the state of every planned or started table, and the
+// answers given. The session ids and start times are not compared.
+func Diff(a, b *Record) []Change {
+	var changes []Change
+	add := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, Change{Field: field, Old: old, New: new})
+		}
+	}
+	add("database", a.Database, b.Database)
+	add("connection", a.DSN, b.DSN)
+	add("cli version", a.CLIVersion, b.CLIVersion)
+	add("status", a.Status, b.Status)
+	add("error", a.Error, b.Error)
+	add("duration", a.Duration().String(), b.Duration().String())
+	add("rows", fmt.Sprint(a.Rows), fmt.Sprint(b.Rows))
+	add("planned tables", fmt.Sprint(len(a.PlanTables)), fmt.Sprint(len(b.PlanTables)))
+
+	for _, t := range tableUnion(a, b) {
+		add("table "+t, a.TableState(t), b.TableState(t))
+	}
+	for i := 0; i < max(len(a.Answers), len(b.Answers)); i++ {
+		add(fmt.Sprintf("answer %d", i+1), answerAt(a, i), answerAt(b, i))
+	}
+	return changes
+}
+
+// Duration returns how long the session ran, rounded to seconds.
+func (r *Record) Duration() time.Duration {
+	if r.EndedAt.IsZero() {
+		return 0
+	}
+	return r.EndedAt.Sub(r.StartedAt).Round(time.Second)
+}
+
+// TableState describes what happened to a table in the session: its result
+// status with the failure reason, "planned" when it was planned but never
+// started, or "-" when the session did not involve it.
+func (r *Record) TableState(table string) string {
+	for _, res := range r.Results {
+		if res.Table == table {
+			if res.Reason != "" {
+				return res.Status + ": " + res.Reason
+			}
+			return res.Status
+		}
+	}
+	for _, t := range r.PlanTables {
+		if t == table {
+			return "planned"
+		}
+	}
+	return "-"
+}
+
+// tableUnion returns every table planned or started by either record, sorted.
+func tableUnion(records ...*Record) []string {
+	seen := map[string]struct{}{}
+	for _, r := range records {
+		for _, t := range r.PlanTables {
+			seen[t] = struct{}{}
+		}
+		for _, res := range r.Results {
+			seen[res.Table] = struct{}{}
+		}
+	}
+	out := make([]string, 0, len(seen))
+	for t := range seen {
+		out = append(out, t)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// answerAt formats the i-th answer of a record, or "-" when there is none.
+func answerAt(r *Record, i int) string {
+	if i >= len(r.Answers) {
+		return "-"
+	}
+	if r.Answers[i].Answer == "" {
+		return "(accepted)"
+	}
+	return r.Answers[i].Answer
+}
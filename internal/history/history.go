@@ -0,0 +1,222 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+// Package history keeps a local record of seeding sessions.
+//
+// Each session is stored as a JSON document under <user dir>/history/<id>.json,
+// where the id is the sortable local session identifier generated by the seed
+// command. A record holds the proposed plan, the answers given to the backend's
+// questions and the outcome of every table, so that runs can be compared later.
+// Records never contain secrets: connection strings are masked before they are
+// saved.
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"seedfast/cli/internal/dsn"
+	"seedfast/cli/internal/userdir"
+)
+
+// ErrNotFound is returned when no session record matches an id.
+var ErrNotFound = errors.New("not found")
+
+// Record describes one seeding session.
+type Record struct {
+	ID         string    `json:"id"`
+	StartedAt  time.Time `json:"started_at"`
+	EndedAt    time.Time `json:"ended_at"`
+	Database   string    `json:"database"`
+	DSN        string    `json:"dsn"`
+	Status     string    `json:"status"`
+	CLIVersion string    `json:"cli_version"`
+	// Tables lists the tables the session started writing to, schema-qualified when known
+	Tables []string `json:"tables"`
+	// Rows is the number of rows reported as affected by successful writes
+	Rows int64 `json:"rows,omitempty"`
+	// PlanTables lists the tables of the last proposed plan
+	PlanTables []string `json:"plan_tables,omitempty"`
+	// Answers lists the replies given to ask_human questions, in order
+	Answers []Answer `json:"answers,omitempty"`
+	// Results holds the outcome of every table the session started, in start order
+	Results []TableResult `json:"results,omitempty"`
+	// Error is the reason the session failed or was interrupted, masked
+	Error string `json:"error,omitempty"`
+}
+
+// Answer sources.
+const (
+	AnswerUser   = "user"
+	AnswerConfig = "config"
+)
+
+// Answer is a reply given to a question asked by the backend.
+type Answer struct {
+	Question string `json:"question"`
+	// Answer is the reply text; empty means the proposal was accepted
+	Answer string `json:"answer"`
+	// Source is AnswerUser for typed replies and AnswerConfig for configured ones
+	Source string    `json:"source"`
+	At     time.Time `json:"at"`
+}
+
+// Table statuses.
+const (
+	TableDone   = "done"
+	TableFailed = "failed"
+	// TableIncomplete marks a table that was started but never reported done
+	TableIncomplete = "incomplete"
+)
+
+// TableResult is the outcome of seeding one table.
+type TableResult struct {
+	Table  string `json:"table"`
+	Status string `json:"status"`
+	// Reason is the failure reason reported by the backend
+	Reason string `json:"reason,omitempty"`
+}
+
+// Dir returns the directory holding session records, creating it if needed.
+func Dir() (string, error) {
+	return userdir.Sub("history")
+}
+
+// recordPath returns the file path of a session record.
+func recordPath(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return "", fmt.Errorf("invalid session id %q", id)
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, id+".json"), nil
+}
+
+// Save writes a session record, replacing any previous record with the same id.
+func Save(r *Record) error {
+	p, err := recordPath(r.ID)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, b, 0o600)
+}
+
+// Load reads a single session record.
+func Load(id string) (*Record, error) {
+	p, err := recordPath(id)
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("session %q %w", id, ErrNotFound)
+		}
+		return nil, err
+	}
+	var r Record
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("read session %q: %w", id, err)
+	}
+	return &r, nil
+}
+
+// List returns all session records, newest first.
+func List() ([]*Record, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []*Record
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		if r, err := Load(strings.TrimSuffix(name, ".json")); err == nil {
+			out = append(out, r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartedAt.After(out[j].StartedAt) })
+	return out, nil
+}
+
+// Find returns the record whose id is id or, failing that, the only record
+// whose id starts with id. "latest" names the most recent record.
+func Find(id string) (*Record, error) {
+	if r, err := Load(id); err == nil {
+		return r, nil
+	}
+	records, err := List()
+	if err != nil {
+		return nil, err
+	}
+	if id == "latest" {
+		if len(records) == 0 {
+			return nil, fmt.Errorf("no sessions recorded yet")
+		}
+		return records[0], nil
+	}
+	var match *Record
+	for _, r := range records {
+		if strings.HasPrefix(r.ID, id) {
+			if match != nil {
+				return nil, fmt.Errorf("session id %q is ambiguous", id)
+			}
+			match = r
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("session %q %w", id, ErrNotFound)
+	}
+	return match, nil
+}
+
+// Latest returns the most recent session that wrote to at least one table of
+// the database named by maskedDSN, or nil when there is none. Sessions match on
+// host, port and database name, so a database with the same name on another
+// server is never picked.
+func Latest(maskedDSN string) (*Record, error) {
+	records, err := List()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range records {
+		if len(r.Tables) > 0 && SameTarget(r.DSN, maskedDSN) {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+// SameTarget reports whether two connection strings name the same database on
+// the same server. Credentials and other parameters are ignored; strings that
+// cannot be parsed never match.
+func SameTarget(a, b string) bool {
+	ai, err := dsn.ParseInfo(a)
+	if err != nil {
+		return false
+	}
+	bi, err := dsn.ParseInfo(b)
+	if err != nil {
+		return false
+	}
+	return ai.Type == bi.Type && ai.Host == bi.Host && ai.Port == bi.Port &&
+		ai.Database == bi.Database && ai.Params["host"] == bi.Params["host"]
+}
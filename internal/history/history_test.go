@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package history
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFind(t *testing.T) {
+	t.Setenv("SEEDFAST_CONFIG_DIR", t.TempDir())
+
+	start := time.Date(2025, 10, 23, 10, 15, 0, 0, time.UTC)
+	for i, id := range []string{"20251023T101500Z-abc123", "20251023T111500Z-abd456", "20251024T090000Z-ff0011"} {
+		if err := Save(&Record{ID: id, StartedAt: start.Add(time.Duration(i) * time.Hour)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		id, want string
+		wantErr  bool
+	}{
+		{id: "20251023T101500Z-abc123", want: "20251023T101500Z-abc123"},
+		{id: "20251024", want: "20251024T090000Z-ff0011"},
+		{id: "latest", want: "20251024T090000Z-ff0011"},
+		{id: "20251023", wantErr: true},
+		{id: "2026", wantErr: true},
+	}
+	for _, tt := range tests {
+		r, err := Find(tt.id)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Find(%q) = %s, want error", tt.id, r.ID)
+			}
+			continue
+		}
+		if err != nil || r.ID != tt.want {
+			t.Errorf("Find(%q) = %v, %v; want %s", tt.id, r, err, tt.want)
+		}
+	}
+	if _, err := Load("20251025T000000Z-000000"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Load(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	start := time.Date(2025, 10, 23, 10, 15, 0, 0, time.UTC)
+	a := &Record{
+		ID: "a", Database: "shop", Status: "failed", CLIVersion: "1.2.0",
+		StartedAt: start, EndedAt: start.Add(90 * time.Second),
+		PlanTables: []string{"public.users", "public.orders"},
+		Answers:    []Answer{{Question: "Agree?", Answer: "only users and orders"}, {Question: "Agree?"}},
+		Results: []TableResult{
+			{Table: "public.users", Status: TableDone},
+			{Table: "public.orders", Status: TableFailed, Reason: "foreign key violation"},
+		},
+	}
+	b := &Record{
+		ID: "b", Database: "shop", Status: "success", CLIVersion: "1.2.0",
+		StartedAt: start.Add(time.Hour), EndedAt: start.Add(time.Hour + 90*time.Second),
+		PlanTables: []string{"public.users", "public.orders", "public.items"},
+		Answers:    []Answer{{Question: "Agree?"}},
+		Results: []TableResult{
+			{Table: "public.users", Status: TableDone},
+			{Table: "public.orders", Status: TableDone},
+		},
+	}
+
+	want := []Change{
+		{Field: "status", Old: "failed", New: "success"},
+		{Field: "planned tables", Old: "2", New: "3"},
+		{Field: "table public.items", Old: "-", New: "planned"},
+		{Field: "table public.orders", Old: "failed: foreign key violation", New: "done"},
+		{Field: "answer 1", Old: "only users and orders", New: "(accepted)"},
+		{Field: "answer 2", Old: "(accepted)", New: "-"},
+	}
+	if got := Diff(a, b); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() =\n%+v\nwant\n%+v", got, want)
+	}
+	if got := Diff(a, a); len(got) != 0 {
+		t.Errorf("Diff(a, a) = %+v, want no changes", got)
+	}
+}
+
+func TestLatest(t *testing.T) {
+	t.Setenv("SEEDFAST_CONFIG_DIR", t.TempDir())
+
+	start := time.Date(2025, 10, 23, 10, 0, 0, 0, time.UTC)
+	records := []*Record{
+		{ID: "1-local", StartedAt: start, DSN: "postgresql://*:*@localhost:5432/app", Tables: []string{"public.users"}},
+		{ID: "2-staging", StartedAt: start.Add(time.Hour), DSN: "postgresql://*:*@staging.example.com:5432/app", Tables: []string{"public.orders"}},
+		{ID: "3-local-empty", StartedAt: start.Add(2 * time.Hour), DSN: "postgresql://*:*@localhost:5432/app"},
+	}
+	for _, r := range records {
+		if err := Save(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		dsn, want string
+	}{
+		{"postgresql://*:*@localhost:5432/app?sslmode=disable", "1-local"},
+		{"postgresql://*:*@staging.example.com:5432/app", "2-staging"},
+		{"postgresql://*:*@localhost:5433/app", ""},
+		{"postgresql://*:*@localhost:5432/other", ""},
+	}
+	for _, tt := range tests {
+		r, err := Latest(tt.dsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := ""
+		if r != nil {
+			got = r.ID
+		}
+		if got != tt.want {
+			t.Errorf("Latest(%q) = %q, want %q", tt.dsn, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+// Package httpclient provides the HTTP client shared by all backend calls.
+//
+// Requests go through one pooled transport that honours HTTPS_PROXY, HTTP_PROXY
+// and NO_PROXY and trusts the system roots plus an optional CA bundle from
+// SEEDFAST_CA_FILE (e.g. for TLS-inspecting corporate proxies). Idempotent
+// requests are retried on network errors, timeouts and 5xx responses with
+// jittered exponential backoff; any request answered with 429 is retried after
+// the server's Retry-After delay.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EnvCAFile names a PEM file with additional trusted CA certificates.
+const EnvCAFile = "SEEDFAST_CA_FILE"
+
+// DefaultTimeout bounds a single request attempt.
+const DefaultTimeout = 15 * time.Second
+
+var (
+	sharedOnce sync.Once
+	shared     http.RoundTripper
+)
+
+// New returns a client whose attempts are each bounded by timeout (DefaultTimeout
+// when zero). The overall duration including retries is bounded by the request
+// context.
+func New(timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &http.Client{Transport: &Transport{Base: sharedTransport(), Timeout: timeout}}
+}
+
+// sharedTransport returns the pooled base transport. A CA bundle that cannot be
+// loaded is reported by every request rather than silently ignored.
+func sharedTransport() http.RoundTripper {
+	sharedOnce.Do(func() {
+		roots, err := RootCAs()
+		if err != nil {
+			shared = errTransport{err}
+			return
+		}
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		t.Proxy = http.ProxyFromEnvironment
+		t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: roots}
+		shared = t
+	})
+	return shared
+}
+
+// RootCAs returns the system roots extended with the certificates from
+// SEEDFAST_CA_FILE, or nil (meaning the system roots) when it is not set.
+func RootCAs() (*x509.CertPool, error) {
+	path := strings.TrimSpace(os.Getenv(EnvCAFile))
+	if path == "" {
+		return nil, nil
+	}
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", EnvCAFile, err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%s: no PEM certificates found in %s", EnvCAFile, path)
+	}
+	return pool, nil
+}
+
+// errTransport fails every request with a configuration error.
+type errTransport struct{ err error }
+
+func (t errTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, t.err
+}
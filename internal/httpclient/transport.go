@@ -0,0 +1,239 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Retry defaults used when the corresponding Transport field is zero.
+const (
+	DefaultMaxAttempts   = 3
+	DefaultMinBackoff    = 250 * time.Millisecond
+	DefaultMaxBackoff    = 5 * time.Second
+	DefaultMaxRetryAfter = 30 * time.Second
+)
+
+// RateLimitError is returned when the server still answers 429 Too Many
+// Requests after the last attempt, or asks to wait longer than the transport
+// is willing to.
+type RateLimitError struct {
+	// Host is the rate-limited server
+	Host string
+	// RetryAfter is the delay requested by the server; zero when not given
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limited by %s: retry after %s", e.Host, e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("rate limited by %s", e.Host)
+}
+
+// Transport retries requests on top of Base.
+//
+// Idempotent requests (GET, HEAD, OPTIONS, TRACE, PUT, DELETE, or any request
+// carrying an Idempotency-Key header) are retried on network errors, timeouts
+// and 500/502/503/504 responses. A 429 response is retried for every method
+// whose body can be replayed, waiting for the Retry-After delay.
+type Transport struct {
+	// Base performs the individual attempts; http.DefaultTransport when nil
+	Base http.RoundTripper
+	// Timeout bounds each attempt including reading the response body (0 = none)
+	Timeout time.Duration
+	// MaxAttempts is the total number of attempts per request
+	MaxAttempts int
+	// MinBackoff and MaxBackoff bound the exponential backoff between attempts
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// MaxRetryAfter is the longest Retry-After delay that is waited for
+	MaxRetryAfter time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attempts := orDefault(t.MaxAttempts, DefaultMaxAttempts)
+	idempotent := isIdempotent(req)
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 1; ; attempt++ {
+		areq := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			areq = req.Clone(req.Context())
+			areq.Body = body
+		}
+
+		resp, err := t.attempt(areq)
+		last := attempt >= attempts
+		var wait time.Duration
+		switch {
+		case err != nil:
+			if last || !idempotent || !replayable || !retryableError(req.Context(), err) {
+				return nil, err
+			}
+			wait = t.backoff(attempt)
+		case resp.StatusCode == http.StatusTooManyRequests:
+			retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			if last || !replayable || retryAfter > orDefault(t.MaxRetryAfter, DefaultMaxRetryAfter) {
+				discard(resp)
+				return nil, &RateLimitError{Host: req.URL.Host, RetryAfter: retryAfter}
+			}
+			wait = t.backoff(attempt)
+			if ok {
+				wait = retryAfter
+			}
+		case retryableStatus(resp.StatusCode) && idempotent && replayable && !last:
+			wait = t.backoff(attempt)
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok && retryAfter <= orDefault(t.MaxRetryAfter, DefaultMaxRetryAfter) {
+				wait = retryAfter
+			}
+		default:
+			return resp, nil
+		}
+
+		if resp != nil {
+			discard(resp)
+		}
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt performs a single round trip bounded by the per-attempt timeout.
+// The timeout stays armed until the response body is closed.
+func (t *Transport) attempt(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if t.Timeout <= 0 {
+		return base.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.Timeout)
+	resp, err := base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// backoff returns the delay before the next attempt: exponential growth from
+// MinBackoff capped at MaxBackoff, with half of it randomised.
+func (t *Transport) backoff(attempt int) time.Duration {
+	d := orDefault(t.MinBackoff, DefaultMinBackoff) << (attempt - 1)
+	if limit := orDefault(t.MaxBackoff, DefaultMaxBackoff); d <= 0 || d > limit {
+		d = limit
+	}
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+// isIdempotent follows the rules net/http uses for retrying requests.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != "" || req.Header.Get("X-Idempotency-Key") != ""
+}
+
+// retryableStatus reports whether a server error is likely transient.
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryableError reports whether a transport error is worth another attempt.
+// Cancellation by the caller, unknown hosts and certificate problems are final.
+func retryableError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var certErr *tls.CertificateVerificationError
+	if errors.As(err, &certErr) {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// parseRetryAfter decodes a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// discard drains a small part of the body so the connection can be reused.
+func discard(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	resp.Body.Close()
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func orDefault[T ~int | ~int64](v, def T) T {
+	if v <= 0 {
+		return def
+	}
+	return v
+}
+
+// cancelBody releases the attempt's timeout once the body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
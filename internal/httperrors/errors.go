@@ -11,6 +11,9 @@ import (
 	"net/url"
 	"strings"
 	"syscall"
+	"time"
+
+	"seedfast/cli/internal/httpclient"
 
 	"github.com/pterm/pterm"
 )
@@ -35,6 +38,12 @@ func displayErrorMessage(err error, context string) {
 	errStr := err.Error()
 
 	// Check for specific error types
+	var rateErr *httpclient.RateLimitError
+	if errors.As(err, &rateErr) {
+		showRateLimitError(context, rateErr.RetryAfter)
+		return
+	}
+
 	if isTimeoutError(err) {
 		showTimeoutError(context)
 		return
@@ -185,7 +194,20 @@ func showSSLError(context string) {
 	pterm.Println()
 	pterm.Println("Try:")
 	pterm.Println("  • Check your system date and time")
-	pterm.Println("  • Verify network proxy settings")
+	pterm.Println("  • Verify network proxy settings (HTTPS_PROXY, NO_PROXY)")
+	pterm.Printf("  • If your proxy inspects TLS, point %s to its CA certificate\n", httpclient.EnvCAFile)
+	pterm.Println()
+}
+
+// showRateLimitError displays a user-friendly message for 429 responses.
+func showRateLimitError(context string, retryAfter time.Duration) {
+	pterm.Printf("🚦 Too many requests while %s\n", context)
+	pterm.Println()
+	if retryAfter > 0 {
+		pterm.Printf("The Seedfast service is rate limiting requests. Please try again in %s.\n", retryAfter.Round(time.Second))
+	} else {
+		pterm.Println("The Seedfast service is rate limiting requests. Please try again in a few moments.")
+	}
 	pterm.Println()
 }
 
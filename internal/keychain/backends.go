@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package keychain
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"seedfast/cli/internal/userdir"
+
+	"github.com/99designs/keyring"
+	"golang.org/x/term"
+)
+
+// Backend names accepted in SEEDFAST_KEYRING_BACKEND.
+const (
+	BackendSecretService = "secret-service"
+	BackendKWallet       = "kwallet"
+	BackendFile          = "file"
+)
+
+// Environment variables controlling credential storage.
+const (
+	// EnvBackend forces a specific keyring backend
+	EnvBackend = "SEEDFAST_KEYRING_BACKEND"
+	// EnvPassphrase supplies the passphrase of the encrypted file backend
+	EnvPassphrase = "SEEDFAST_KEYRING_PASSPHRASE"
+)
+
+// requestedBackend returns the backend forced via SEEDFAST_KEYRING_BACKEND, if any.
+func requestedBackend() (keyring.BackendType, error) {
+	switch name := strings.ToLower(strings.TrimSpace(os.Getenv(EnvBackend))); name {
+	case "":
+		return "", nil
+	case BackendSecretService:
+		return keyring.SecretServiceBackend, nil
+	case BackendKWallet:
+		return keyring.KWalletBackend, nil
+	case BackendFile:
+		return keyring.FileBackend, nil
+	default:
+		return "", fmt.Errorf("unsupported %s %q (use %s, %s or %s)", EnvBackend, name, BackendSecretService, BackendKWallet, BackendFile)
+	}
+}
+
+// linuxBackends returns the backends tried on Linux and other Unix systems, in order.
+// Desktop sessions use the Secret Service (GNOME Keyring, KeePassXC) or KWallet over
+// D-Bus; headless machines and containers fall back to the encrypted file backend.
+func linuxBackends() []keyring.BackendType {
+	return []keyring.BackendType{
+		keyring.SecretServiceBackend,
+		keyring.KWalletBackend,
+		keyring.FileBackend,
+	}
+}
+
+// fileKeyringDir returns the directory holding the encrypted file keyring.
+func fileKeyringDir() (string, error) {
+	base, err := userdir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "keyring"), nil
+}
+
+// filePassphrase supplies the passphrase for the encrypted file backend, reading
+// SEEDFAST_KEYRING_PASSPHRASE first and prompting on a terminal otherwise.
+func filePassphrase(prompt string) (string, error) {
+	if p := os.Getenv(EnvPassphrase); p != "" {
+		return p, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("encrypted credential file is locked: set %s to its passphrase", EnvPassphrase)
+	}
+	p, err := keyring.TerminalPrompt(prompt)
+	if err != nil {
+		return "", err
+	}
+	if p == "" {
+		return "", errors.New("empty passphrase")
+	}
+	return p, nil
+}
+
+// unsupportedBackendError explains why no credential store could be opened.
+func unsupportedBackendError() error {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		return errors.New("secure storage unavailable")
+	}
+	return fmt.Errorf("no secure storage available: start a Secret Service provider (e.g. gnome-keyring) or KWallet, or set %s=%s and %s", EnvBackend, BackendFile, EnvPassphrase)
+}
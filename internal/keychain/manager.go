@@ -6,14 +6,17 @@
 // providing a unified interface for storing and retrieving sensitive data such as
 // authentication tokens, database credentials, and other secrets.
 //
-// The package supports multiple operating systems including macOS Keychain and
-// Windows Credential Manager, with thread-safe operations and proper error handling.
+// The package supports macOS Keychain, Windows Credential Manager and, on Linux,
+// the freedesktop Secret Service or KWallet with an encrypted-file fallback for
+// headless machines, with thread-safe operations and proper error handling.
 // It provides both individual credential management and bulk operations for
 // authentication state and database connections.
 package keychain
 
 import (
 	"errors"
+	"fmt"
+	"os"
 	"runtime"
 	"strings"
 	"sync"
@@ -55,8 +58,8 @@ const (
 
 // NewManager creates a new keychain manager with the OS keyring initialized.
 func NewManager() (*Manager, error) {
-	// Try native security backend first on macOS
-	if runtime.GOOS == "darwin" {
+	// Try native security backend first on macOS, unless a backend is forced
+	if runtime.GOOS == "darwin" && os.Getenv(EnvBackend) == "" {
 		backend, err := newSecurityBackend()
 		if err == nil {
 			return &Manager{backend: backend}, nil
@@ -105,31 +108,48 @@ func MustGetManager() *Manager {
 	return manager
 }
 
-// openRing opens the OS keyring using native platform backends only.
-// Forces use of macOS Keychain or Windows Credential Manager - no file fallback.
+// openRing opens the OS keyring using native platform backends.
+// macOS uses the Keychain (or pass), Windows the Credential Manager. Linux and
+// other Unix systems use the Secret Service or KWallet, falling back to an
+// encrypted file for headless machines. SEEDFAST_KEYRING_BACKEND forces a backend.
 func openRing() (keyring.Keyring, error) {
-	// Only support darwin/windows platforms
-	if runtime.GOOS != "darwin" && runtime.GOOS != "windows" {
-		return nil, errors.New("secure storage not supported on this OS (macOS/Windows only)")
+	forced, err := requestedBackend()
+	if err != nil {
+		return nil, err
 	}
 
-	// Use platform-specific native backends only
 	var allowedBackends []keyring.BackendType
-	if runtime.GOOS == "darwin" {
+	switch {
+	case forced != "":
+		allowedBackends = []keyring.BackendType{forced}
+	case runtime.GOOS == "darwin":
 		// Try macOS Keychain first, then pass (password store) as fallback
 		// Pass requires 'pass' utility installed: brew install pass
 		allowedBackends = []keyring.BackendType{
 			keyring.KeychainBackend,
 			keyring.PassBackend,
 		}
-	} else if runtime.GOOS == "windows" {
+	case runtime.GOOS == "windows":
 		allowedBackends = []keyring.BackendType{keyring.WinCredBackend}
+	default:
+		allowedBackends = linuxBackends()
+	}
+
+	fileDir, err := fileKeyringDir()
+	if err != nil {
+		return nil, err
 	}
 
 	cfg := keyring.Config{
 		ServiceName:     ServiceName,
 		AllowedBackends: allowedBackends,
 		PassPrefix:      ServiceName,
+		// Secret Service collection and KWallet folder share the service name
+		LibSecretCollectionName: ServiceName,
+		KWalletAppID:            ServiceName,
+		KWalletFolder:           ServiceName,
+		FileDir:                 fileDir,
+		FilePasswordFunc:        filePassphrase,
 	}
 
 	// Hint prefixes where supported to minimize namespace collisions
@@ -139,10 +159,13 @@ func openRing() (keyring.Keyring, error) {
 
 	ring, err := keyring.Open(cfg)
 	if err != nil {
+		if forced != "" {
+			return nil, fmt.Errorf("keyring backend %q unavailable: %w", forced, err)
+		}
 		if runtime.GOOS == "darwin" {
 			return nil, errors.New("macOS Keychain unavailable. On macOS 26.0+, install 'pass': brew install pass gnupg && gpg --generate-key && pass init <gpg-key-id>")
 		}
-		return nil, err
+		return nil, unsupportedBackendError()
 	}
 
 	return ring, nil
@@ -292,7 +315,7 @@ func (m *Manager) LoadAuthState() ([]byte, error) {
 
 	it, err := m.ring.Get(KeyAuthState)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, keyring.ErrKeyNotFound) || strings.Contains(err.Error(), "not found") {
 			return nil, nil
 		}
 		return nil, err
@@ -315,68 +338,68 @@ func (m *Manager) ClearAuthState() error {
 	return nil
 }
 
-// SaveDBDSN stores the database DSN in the keychain.
+// SaveDBDSN stores the database DSN of the default connection profile.
 // This method is thread-safe.
 func (m *Manager) SaveDBDSN(dsn string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if m.backend != nil {
-		return m.backend.Set(KeyDBDSN, dsn)
-	}
-
-	return m.ring.Set(keyring.Item{Key: KeyDBDSN, Data: []byte(dsn)})
+	return m.saveProfile(m.defaultProfileName(), dsn)
 }
 
-// LoadDBDSN retrieves the database DSN from the keychain.
+// LoadDBDSN retrieves the database DSN of the default connection profile.
 // This method is thread-safe.
 func (m *Manager) LoadDBDSN() (string, error) {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-
-	if m.backend != nil {
-		return m.backend.Get(KeyDBDSN)
-	}
-
-	it, err := m.ring.Get(KeyDBDSN)
+	idx, err := m.loadIndex()
+	m.mu.RUnlock()
 	if err != nil {
 		return "", err
 	}
-	return string(it.Data), nil
+	if idx.Default == "" {
+		return "", errors.New("no default database connection")
+	}
+	return m.LoadProfileDSN(idx.Default)
 }
 
-// ClearDB removes DB-related secrets from the keychain.
+// ClearDB removes all connection profiles from the keychain.
 // This method is thread-safe.
 func (m *Manager) ClearDB() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if m.backend != nil {
-		_ = m.backend.Delete(KeyDBDSN)
-		return nil
-	}
-
-	_ = m.ring.Remove(KeyDBDSN)
+	m.clearProfiles()
 	return nil
 }
 
+// clearProfiles deletes every stored DSN and the profile index.
+// Callers must hold m.mu.
+func (m *Manager) clearProfiles() {
+	if idx, err := m.loadIndex(); err == nil {
+		for _, name := range idx.Names {
+			m.del(profileKey(name))
+		}
+	}
+	m.del(KeyDBDSN)
+	m.del(KeyDBProfiles)
+}
+
 // ClearAll removes all secrets from the keychain.
 // This method is thread-safe and should be used with caution.
 func (m *Manager) ClearAll() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.clearProfiles()
 	if m.backend != nil {
 		_ = m.backend.Delete(KeyAccessToken)
 		_ = m.backend.Delete(KeyRefreshToken)
 		_ = m.backend.Delete(KeyAuthState)
-		_ = m.backend.Delete(KeyDBDSN)
 		return nil
 	}
 
 	_ = m.ring.Remove(KeyAccessToken)
 	_ = m.ring.Remove(KeyRefreshToken)
 	_ = m.ring.Remove(KeyAuthState)
-	_ = m.ring.Remove(KeyDBDSN)
 	return nil
 }
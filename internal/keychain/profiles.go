@@ -0,0 +1,244 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package keychain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/99designs/keyring"
+)
+
+// DefaultProfile is the name of the connection profile that holds the DSN saved
+// before named profiles existed. It is stored under KeyDBDSN.
+const DefaultProfile = "default"
+
+// KeyDBProfiles stores the profile index (names and the default profile).
+const KeyDBProfiles = "db_profiles"
+
+// profileNameRegex restricts profile names to characters safe in keychain keys.
+var profileNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+
+// profileIndex lists the stored connection profiles. Names are not secret, but
+// keeping the index next to the DSNs lets ClearDB remove everything at once.
+type profileIndex struct {
+	Default string   `json:"default"`
+	Names   []string `json:"names"`
+}
+
+// ValidateProfileName reports whether name can be used as a connection profile name.
+func ValidateProfileName(name string) error {
+	if !profileNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid connection name %q: use letters, digits, '-' and '_' (max 64)", name)
+	}
+	return nil
+}
+
+// profileKey returns the keychain key holding the DSN of a profile.
+func profileKey(name string) string {
+	if name == DefaultProfile {
+		return KeyDBDSN
+	}
+	return KeyDBDSN + "." + name
+}
+
+// isNotFound reports whether err means the key does not exist.
+func isNotFound(err error) bool {
+	if errors.Is(err, keyring.ErrKeyNotFound) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "not found") || strings.Contains(msg, "could not be found")
+}
+
+// set, get and del dispatch to the native backend or the keyring library.
+// Callers must hold m.mu.
+func (m *Manager) set(key, value string) error {
+	if m.backend != nil {
+		return m.backend.Set(key, value)
+	}
+	return m.ring.Set(keyring.Item{Key: key, Data: []byte(value)})
+}
+
+func (m *Manager) get(key string) (string, error) {
+	if m.backend != nil {
+		return m.backend.Get(key)
+	}
+	it, err := m.ring.Get(key)
+	if err != nil {
+		return "", err
+	}
+	return string(it.Data), nil
+}
+
+func (m *Manager) del(key string) {
+	if m.backend != nil {
+		_ = m.backend.Delete(key)
+		return
+	}
+	_ = m.ring.Remove(key)
+}
+
+// loadIndex reads the profile index. A missing index is derived from the
+// legacy single DSN, if one is stored. Callers must hold m.mu.
+func (m *Manager) loadIndex() (profileIndex, error) {
+	var idx profileIndex
+	raw, err := m.get(KeyDBProfiles)
+	if err == nil && raw != "" {
+		if err := json.Unmarshal([]byte(raw), &idx); err != nil {
+			return idx, fmt.Errorf("read connection profiles: %w", err)
+		}
+		return idx, nil
+	}
+	if err != nil && !isNotFound(err) {
+		return idx, err
+	}
+	if v, err := m.get(KeyDBDSN); err == nil && v != "" {
+		idx.Names = []string{DefaultProfile}
+		idx.Default = DefaultProfile
+	}
+	return idx, nil
+}
+
+// saveIndex writes the profile index. Callers must hold m.mu.
+func (m *Manager) saveIndex(idx profileIndex) error {
+	sort.Strings(idx.Names)
+	b, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+	return m.set(KeyDBProfiles, string(b))
+}
+
+// defaultProfileName returns the profile SaveDBDSN and LoadDBDSN operate on.
+// Callers must hold m.mu.
+func (m *Manager) defaultProfileName() string {
+	if idx, err := m.loadIndex(); err == nil && idx.Default != "" {
+		return idx.Default
+	}
+	return DefaultProfile
+}
+
+// SaveProfileDSN stores the DSN of a named connection profile. The first
+// profile saved becomes the default.
+// This method is thread-safe.
+func (m *Manager) SaveProfileDSN(name, dsn string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.saveProfile(name, dsn)
+}
+
+// saveProfile stores a profile DSN and records it in the index.
+// Callers must hold m.mu.
+func (m *Manager) saveProfile(name, dsn string) error {
+	idx, err := m.loadIndex()
+	if err != nil {
+		return err
+	}
+	if err := m.set(profileKey(name), dsn); err != nil {
+		return err
+	}
+	if !containsName(idx.Names, name) {
+		idx.Names = append(idx.Names, name)
+	}
+	if idx.Default == "" {
+		idx.Default = name
+	}
+	return m.saveIndex(idx)
+}
+
+// LoadProfileDSN retrieves the DSN of a named connection profile.
+// This method is thread-safe.
+func (m *Manager) LoadProfileDSN(name string) (string, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	v, err := m.get(profileKey(name))
+	if err != nil {
+		if isNotFound(err) {
+			return "", fmt.Errorf("connection %q not found", name)
+		}
+		return "", err
+	}
+	return v, nil
+}
+
+// ListProfiles returns the stored profile names, sorted, and the default profile.
+// This method is thread-safe.
+func (m *Manager) ListProfiles() (names []string, defaultName string, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	idx, err := m.loadIndex()
+	if err != nil {
+		return nil, "", err
+	}
+	sort.Strings(idx.Names)
+	return idx.Names, idx.Default, nil
+}
+
+// SetDefaultProfile makes the named profile the default connection.
+// This method is thread-safe.
+func (m *Manager) SetDefaultProfile(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	idx, err := m.loadIndex()
+	if err != nil {
+		return err
+	}
+	if !containsName(idx.Names, name) {
+		return fmt.Errorf("connection %q not found", name)
+	}
+	idx.Default = name
+	return m.saveIndex(idx)
+}
+
+// RemoveProfile deletes a named connection profile. Removing the default
+// profile leaves no default until another one is selected or saved.
+// This method is thread-safe.
+func (m *Manager) RemoveProfile(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	idx, err := m.loadIndex()
+	if err != nil {
+		return err
+	}
+	if !containsName(idx.Names, name) {
+		return fmt.Errorf("connection %q not found", name)
+	}
+	m.del(profileKey(name))
+	kept := idx.Names[:0]
+	for _, n := range idx.Names {
+		if n != name {
+			kept = append(kept, n)
+		}
+	}
+	idx.Names = kept
+	if idx.Default == name {
+		idx.Default = ""
+	}
+	return m.saveIndex(idx)
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
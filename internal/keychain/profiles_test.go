@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package keychain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConnectionProfiles(t *testing.T) {
+	t.Setenv("SEEDFAST_CONFIG_DIR", t.TempDir())
+	t.Setenv(EnvBackend, BackendFile)
+	t.Setenv(EnvPassphrase, "test")
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A DSN saved before profiles existed becomes the default profile
+	if err := m.set(KeyDBDSN, "postgres://legacy/db"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := m.LoadDBDSN(); err != nil || got != "postgres://legacy/db" {
+		t.Fatalf("LoadDBDSN() = %q, %v", got, err)
+	}
+
+	if err := m.SaveProfileDSN("staging", "postgres://staging/db"); err != nil {
+		t.Fatal(err)
+	}
+	names, def, err := m.ListProfiles()
+	if err != nil || !reflect.DeepEqual(names, []string{"default", "staging"}) || def != "default" {
+		t.Fatalf("ListProfiles() = %v, %q, %v", names, def, err)
+	}
+
+	if err := m.SetDefaultProfile("staging"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := m.LoadDBDSN(); got != "postgres://staging/db" {
+		t.Fatalf("LoadDBDSN() after use = %q", got)
+	}
+	// SaveDBDSN updates the default profile
+	if err := m.SaveDBDSN("postgres://staging/db2"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := m.LoadProfileDSN("staging"); got != "postgres://staging/db2" {
+		t.Fatalf("LoadProfileDSN(staging) = %q", got)
+	}
+	// Saving the "default" profile leaves another default profile untouched
+	if err := m.SaveProfileDSN(DefaultProfile, "postgres://local/db"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := m.LoadDBDSN(); got != "postgres://staging/db2" {
+		t.Fatalf("LoadDBDSN() after saving default = %q", got)
+	}
+
+	if err := m.RemoveProfile("staging"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.LoadDBDSN(); err == nil {
+		t.Fatal("expected no default after removing it")
+	}
+	if err := m.SetDefaultProfile("missing"); err == nil {
+		t.Fatal("expected error for unknown profile")
+	}
+	if err := m.SaveProfileDSN("../evil", "x"); err == nil {
+		t.Fatal("expected error for invalid name")
+	}
+
+	if err := m.ClearDB(); err != nil {
+		t.Fatal(err)
+	}
+	if names, _, _ := m.ListProfiles(); len(names) != 0 {
+		t.Fatalf("profiles left after ClearDB: %v", names)
+	}
+}
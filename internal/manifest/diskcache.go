@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"seedfast/cli/internal/userdir"
+)
+
+// Disk cache lifetimes. A cached manifest younger than CacheTTL is used as is.
+// Up to CacheTTL+CacheStaleWindow it is still used, while a fresh copy is
+// fetched in the background for the next run; older entries are refetched
+// before use.
+const (
+	CacheTTL         = time.Hour
+	CacheStaleWindow = 7 * 24 * time.Hour
+)
+
+// now is replaced in tests.
+var now = time.Now
+
+// diskEntry is the on-disk form of a cached manifest. The document is stored
+// exactly as received together with its signature, which is verified again
+// on every load, so a modified or expired cache file is rejected.
+type diskEntry struct {
+	Source    string    `json:"source"`
+	FetchedAt time.Time `json:"fetched_at"`
+	Body      []byte    `json:"body"`
+	Signature string    `json:"signature,omitempty"`
+	KeyID     string    `json:"key_id,omitempty"`
+}
+
+// cachePath returns the cache file for a manifest source. Each source has its
+// own file so that switching between backends does not mix endpoints.
+func cachePath(source string) (string, error) {
+	dir, err := userdir.Sub("cache")
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(source))
+	return filepath.Join(dir, "manifest-"+hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+// loadDisk returns the cached manifest for source and when it was fetched.
+func loadDisk(source string) (*Manifest, time.Time, error) {
+	p, err := cachePath(source)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	var e diskEntry
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil, time.Time{}, err
+	}
+	if e.Source != source {
+		return nil, time.Time{}, errors.New("manifest cache belongs to another source")
+	}
+	m, err := parse(&document{Body: e.Body, Signature: e.Signature, KeyID: e.KeyID})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return m, e.FetchedAt, nil
+}
+
+// saveDisk caches a verified document. The file is replaced atomically so that
+// concurrent runs never read a partial entry.
+func saveDisk(source string, doc *document) error {
+	p, err := cachePath(source)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(diskEntry{Source: source, FetchedAt: now().UTC(), Body: doc.Body, Signature: doc.Signature, KeyID: doc.KeyID})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".manifest-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), p)
+}
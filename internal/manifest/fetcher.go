@@ -15,7 +15,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"strings"
 	"time"
+
+	"seedfast/cli/internal/httpclient"
 )
 
 const manifestPublicKeyPEM = `-----BEGIN PUBLIC KEY-----
@@ -28,13 +32,37 @@ MVqf+mMUwoIzRW3qvx0cYeUtH20WEpncI4Dw+RNiBa3lee0YZRlXEuWCOk7nCwFC
 fwIDAQAB
 -----END PUBLIC KEY-----`
 
-const manifestURL = "https://seedfa.st/cli-endpoints.json"
+// document is a manifest as received, before parsing.
+type document struct {
+	Body []byte
+	// Signature is the base64 RSA-SHA256 signature of Body, if the source provided one
+	Signature string
+	// KeyID names the trusted key the signature was made with; empty when unknown
+	KeyID string
+}
 
-// fetchFromServer retrieves the manifest from the server with signature verification.
-func fetchFromServer(ctx context.Context) (*Manifest, error) {
-	client := &http.Client{Timeout: 15 * time.Second}
+// clockSkew is the tolerance applied to the manifest validity window.
+const clockSkew = 5 * time.Minute
+
+// fetch retrieves the manifest document from an http(s) URL or a local file.
+// A local file may be accompanied by a detached signature in <file>.sig.
+func fetch(ctx context.Context, source string) (*document, error) {
+	if !isRemote(source) {
+		path := localPath(source)
+		body, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read manifest: %w", err)
+		}
+		doc := &document{Body: body}
+		if sig, err := os.ReadFile(path + ".sig"); err == nil {
+			doc.Signature = strings.TrimSpace(string(sig))
+		}
+		return doc, nil
+	}
+
+	client := httpclient.New(httpclient.DefaultTimeout)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", manifestURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", source, nil)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
@@ -58,17 +86,29 @@ func fetchFromServer(ctx context.Context) (*Manifest, error) {
 	if err != nil {
 		return nil, fmt.Errorf("read response: %w", err)
 	}
+	return &document{
+		Body:      body,
+		Signature: resp.Header.Get("X-Manifest-Signature"),
+		KeyID:     resp.Header.Get("X-Manifest-Key-Id"),
+	}, nil
+}
 
-	// Verify signature if header is present
-	if sig := resp.Header.Get("X-Manifest-Signature"); sig != "" {
-		if err := verifySignature(body, sig); err != nil {
+// parse verifies the signature of a document and decodes it. Unsigned
+// documents are rejected unless SEEDFAST_ALLOW_UNSIGNED_MANIFEST is set, so
+// that a stripped signature cannot redirect the CLI to another backend.
+func parse(doc *document) (*Manifest, error) {
+	switch {
+	case doc.Signature != "":
+		if err := verifySignature(doc.Body, doc.Signature, doc.KeyID); err != nil {
 			return nil, fmt.Errorf("signature verification failed: %w", err)
 		}
+	case !AllowUnsigned():
+		return nil, fmt.Errorf("manifest is not signed (set %s=1 to accept unsigned manifests from a local development backend)", EnvAllowUnsigned)
 	}
 
 	// Parse JSON
 	var manifest Manifest
-	if err := json.Unmarshal(body, &manifest); err != nil {
+	if err := json.Unmarshal(doc.Body, &manifest); err != nil {
 		return nil, fmt.Errorf("parse manifest JSON: %w", err)
 	}
 
@@ -79,41 +119,62 @@ func fetchFromServer(ctx context.Context) (*Manifest, error) {
 	if manifest.GRPC.Agent == "" {
 		return nil, fmt.Errorf("invalid manifest: missing grpc.agent field")
 	}
+	t := now()
+	if !manifest.ExpiresAt.IsZero() && t.After(manifest.ExpiresAt.Add(clockSkew)) {
+		return nil, fmt.Errorf("manifest expired at %s", manifest.ExpiresAt.Format(time.RFC3339))
+	}
+	if !manifest.IssuedAt.IsZero() && manifest.IssuedAt.After(t.Add(clockSkew)) {
+		return nil, fmt.Errorf("manifest issued in the future (%s); check the system clock", manifest.IssuedAt.Format(time.RFC3339))
+	}
 
 	return &manifest, nil
 }
 
-// verifySignature validates the RSA-SHA256 signature of the manifest.
-func verifySignature(body []byte, signatureB64 string) error {
+// verifySignature validates the RSA-SHA256 signature of the manifest against
+// the trusted key named keyID, or any trusted key when keyID is empty.
+func verifySignature(body []byte, signatureB64, keyID string) error {
 	// Decode base64 signature
 	sig, err := base64.StdEncoding.DecodeString(signatureB64)
 	if err != nil {
 		return fmt.Errorf("decode signature: %w", err)
 	}
 
-	// Parse public key
-	block, _ := pem.Decode([]byte(manifestPublicKeyPEM))
+	keys := candidateKeys(keyID)
+	if len(keys) == 0 {
+		return fmt.Errorf("unknown signing key %q", keyID)
+	}
+
+	// Compute SHA256 hash of body
+	hash := sha256.Sum256(body)
+
+	var lastErr error
+	for _, keyPEM := range keys {
+		rsaPubKey, err := parsePublicKey(keyPEM)
+		if err != nil {
+			return err
+		}
+		if lastErr = rsa.VerifyPKCS1v15(rsaPubKey, crypto.SHA256, hash[:], sig); lastErr == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("signature mismatch: %w", lastErr)
+}
+
+// parsePublicKey decodes a PEM-encoded RSA public key.
+func parsePublicKey(keyPEM string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
 	if block == nil {
-		return fmt.Errorf("failed to parse PEM block")
+		return nil, fmt.Errorf("failed to parse PEM block")
 	}
 
 	pubKey, err := x509.ParsePKIXPublicKey(block.Bytes)
 	if err != nil {
-		return fmt.Errorf("parse public key: %w", err)
+		return nil, fmt.Errorf("parse public key: %w", err)
 	}
 
 	rsaPubKey, ok := pubKey.(*rsa.PublicKey)
 	if !ok {
-		return fmt.Errorf("not an RSA public key")
+		return nil, fmt.Errorf("not an RSA public key")
 	}
-
-	// Compute SHA256 hash of body
-	hash := sha256.Sum256(body)
-
-	// Verify signature
-	if err := rsa.VerifyPKCS1v15(rsaPubKey, crypto.SHA256, hash[:], sig); err != nil {
-		return fmt.Errorf("signature mismatch: %w", err)
-	}
-
-	return nil
+	return rsaPubKey, nil
 }
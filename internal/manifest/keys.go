@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package manifest
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// EnvAllowUnsigned accepts manifests without a signature when set to 1 or
+// true. It exists for local development against a backend that does not sign
+// its manifest; a present signature is still verified.
+const EnvAllowUnsigned = "SEEDFAST_ALLOW_UNSIGNED_MANIFEST"
+
+// trustedKeys maps key IDs to the public keys manifests may be signed with.
+// The server names its key in the X-Manifest-Key-Id header. To rotate, a
+// release ships the incoming key next to the current one before the server
+// switches; the outgoing key is removed once no supported CLI relies on it.
+var trustedKeys = map[string]string{
+	"seedfast-2025-01": manifestPublicKeyPEM,
+}
+
+// AllowUnsigned reports whether unsigned manifests were explicitly allowed.
+func AllowUnsigned() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(EnvAllowUnsigned))) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+// candidateKeys returns the keys to verify a signature with: the named key, or
+// every trusted key when the source did not name one (manifest files and
+// servers predating key IDs). An unknown key ID yields no keys.
+func candidateKeys(keyID string) []string {
+	if keyID != "" {
+		if pem, ok := trustedKeys[keyID]; ok {
+			return []string{pem}
+		}
+		return nil
+	}
+	ids := make([]string, 0, len(trustedKeys))
+	for id := range trustedKeys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = trustedKeys[id]
+	}
+	return keys
+}
@@ -5,12 +5,22 @@ package manifest
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"seedfast/cli/internal/httperrors"
 )
 
+// revalidating tracks background refreshes of stale disk cache entries.
+var (
+	revalidating   sync.WaitGroup
+	revalidateOnce sync.Once
+)
+
 // GetEndpoints returns the manifest endpoints, using the RAM cache if available.
-// If not cached, it fetches from the server and caches the result.
+// Otherwise a remote manifest is served from the disk cache while it is fresh
+// (see CacheTTL and CacheStaleWindow) and fetched from the server when it is
+// missing or expired; local manifest files are read directly.
 // This function is the main entry point for retrieving backend configuration.
 func GetEndpoints(ctx context.Context) (*Manifest, error) {
 	// Check RAM cache first
@@ -18,8 +28,21 @@ func GetEndpoints(ctx context.Context) (*Manifest, error) {
 		return cached, nil
 	}
 
-	// Fetch from server
-	manifest, err := fetchFromServer(ctx)
+	source := Source()
+	if isRemote(source) {
+		if m, fetchedAt, err := loadDisk(source); err == nil {
+			age := now().Sub(fetchedAt)
+			if age < CacheTTL+CacheStaleWindow {
+				if age >= CacheTTL {
+					revalidate(source)
+				}
+				SetCached(m)
+				return m, nil
+			}
+		}
+	}
+
+	manifest, err := load(ctx, source)
 	if err != nil {
 		return nil, httperrors.FormatNetworkError(err, "fetching server configuration")
 	}
@@ -29,3 +52,34 @@ func GetEndpoints(ctx context.Context) (*Manifest, error) {
 
 	return manifest, nil
 }
+
+// load fetches and verifies the manifest, caching remote manifests on disk.
+func load(ctx context.Context, source string) (*Manifest, error) {
+	doc, err := fetch(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	m, err := parse(doc)
+	if err != nil {
+		return nil, err
+	}
+	if isRemote(source) {
+		_ = saveDisk(source, doc) // best effort; the next run fetches again
+	}
+	return m, nil
+}
+
+// revalidate refreshes a stale disk cache entry in the background, at most once
+// per process. The result is only written to disk; this run keeps using the
+// stale manifest. If the process exits first, the next run tries again.
+func revalidate(source string) {
+	revalidateOnce.Do(func() {
+		revalidating.Add(1)
+		go func() {
+			defer revalidating.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+			_, _ = load(ctx, source)
+		}()
+	})
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package manifest
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testManifest = `{"version":1,"grpc":{"agent_origin":"https://agent.staging.example.com"},"http":{"account_whoami":"/api/cli/me"}}`
+
+// trustTestKey generates a signing key and trusts it under id for the test.
+func trustTestKey(t *testing.T, id string) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trustedKeys[id] = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	t.Cleanup(func() { delete(trustedKeys, id) })
+	return key
+}
+
+func sign(t *testing.T, key *rsa.PrivateKey, body []byte) string {
+	t.Helper()
+	hash := sha256.Sum256(body)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+// setup isolates the user directory and process-wide state, and serves
+// testManifest signed with a trusted test key, counting requests.
+func setup(t *testing.T) (*atomic.Int32, string) {
+	t.Helper()
+	t.Setenv("SEEDFAST_CONFIG_DIR", t.TempDir())
+	t.Setenv(EnvManifestURL, "")
+	t.Setenv(EnvAllowUnsigned, "")
+	key := trustTestKey(t, "test-1")
+	sig := sign(t, key, []byte(testManifest))
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("X-Manifest-Signature", sig)
+		w.Header().Set("X-Manifest-Key-Id", "test-1")
+		w.Write([]byte(testManifest))
+	}))
+	reset := func() {
+		revalidating.Wait()
+		ClearCache()
+		SetSource("")
+		revalidateOnce = sync.Once{}
+		now = time.Now
+	}
+	reset()
+	t.Cleanup(func() {
+		srv.Close()
+		reset()
+	})
+	return &hits, srv.URL
+}
+
+func TestGetEndpointsDiskCache(t *testing.T) {
+	hits, url := setup(t)
+	t.Setenv(EnvManifestURL, url)
+	ctx := context.Background()
+
+	m, err := GetEndpoints(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.HTTPBaseURL() != "https://staging.example.com" || hits.Load() != 1 {
+		t.Fatalf("first call: base %q, %d fetches", m.HTTPBaseURL(), hits.Load())
+	}
+
+	// A new process within the TTL reads the disk cache without network access
+	ClearCache()
+	if _, err := GetEndpoints(ctx); err != nil || hits.Load() != 1 {
+		t.Fatalf("fresh cache: err %v, %d fetches", err, hits.Load())
+	}
+
+	// Stale entries are served and revalidated in the background
+	start := time.Now()
+	now = func() time.Time { return start.Add(CacheTTL + time.Minute) }
+	ClearCache()
+	if _, err := GetEndpoints(ctx); err != nil {
+		t.Fatal(err)
+	}
+	revalidating.Wait()
+	if hits.Load() != 2 {
+		t.Fatalf("stale cache: %d fetches, want background revalidation", hits.Load())
+	}
+
+	// Expired entries are fetched before use
+	// (the revalidation above stored a copy fetched at start+TTL+1m)
+	now = func() time.Time { return start.Add(3*CacheTTL + CacheStaleWindow) }
+	ClearCache()
+	if _, err := GetEndpoints(ctx); err != nil || hits.Load() != 3 {
+		t.Fatalf("expired cache: err %v, %d fetches", err, hits.Load())
+	}
+}
+
+func TestGetEndpointsRejectsTamperedCache(t *testing.T) {
+	hits, url := setup(t)
+	SetSource(url)
+	ctx := context.Background()
+	if _, err := GetEndpoints(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Redirect the cached endpoints without a valid signature
+	p, err := cachePath(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var e diskEntry
+	b, _ := os.ReadFile(p)
+	json.Unmarshal(b, &e)
+	e.Body = []byte(`{"version":1,"grpc":{"agent_origin":"https://agent.evil.example.com"}}`)
+	e.Signature = "bm90IGEgc2lnbmF0dXJl"
+	b, _ = json.Marshal(e)
+	os.WriteFile(p, b, 0o600)
+
+	ClearCache()
+	m, err := GetEndpoints(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.HTTPBaseURL() != "https://staging.example.com" || hits.Load() != 2 {
+		t.Fatalf("tampered cache used: base %q, %d fetches", m.HTTPBaseURL(), hits.Load())
+	}
+}
+
+func TestGetEndpointsLocalFile(t *testing.T) {
+	hits, _ := setup(t)
+	path := filepath.Join(t.TempDir(), "endpoints.json")
+	if err := os.WriteFile(path, []byte(testManifest), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// Local files are signed with a detached <file>.sig; any trusted key may match
+	key := trustTestKey(t, "test-2")
+	if err := os.WriteFile(path+".sig", []byte(sign(t, key, []byte(testManifest))+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	SetSource(path)
+	t.Setenv(EnvManifestURL, "https://ignored.example.com/manifest.json")
+	if Source() != path {
+		t.Fatalf("Source() = %q, want flag override", Source())
+	}
+
+	m, err := GetEndpoints(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.GRPCAddress() != "agent.staging.example.com" || hits.Load() != 0 {
+		t.Fatalf("local manifest: %q, %d fetches", m.GRPCAddress(), hits.Load())
+	}
+}
+
+func TestParseRequiresSignature(t *testing.T) {
+	setup(t)
+	key := trustTestKey(t, "current")
+	body := []byte(testManifest)
+
+	if _, err := parse(&document{Body: body}); err == nil {
+		t.Fatal("unsigned manifest accepted")
+	}
+	t.Setenv(EnvAllowUnsigned, "1")
+	if _, err := parse(&document{Body: body}); err != nil {
+		t.Fatalf("unsigned manifest with opt-out: %v", err)
+	}
+	// The opt-out does not weaken verification of signatures that are present
+	if _, err := parse(&document{Body: body, Signature: sign(t, trustTestKey(t, "untrusted"), body), KeyID: "current"}); err == nil {
+		t.Fatal("manifest signed with another key accepted")
+	}
+	t.Setenv(EnvAllowUnsigned, "")
+
+	sig := sign(t, key, body)
+	for _, tt := range []struct {
+		keyID string
+		ok    bool
+	}{{"current", true}, {"", true}, {"test-1", false}, {"retired", false}} {
+		_, err := parse(&document{Body: body, Signature: sig, KeyID: tt.keyID})
+		if (err == nil) != tt.ok {
+			t.Errorf("key ID %q: err = %v, want ok %v", tt.keyID, err, tt.ok)
+		}
+	}
+}
+
+func TestParseExpiry(t *testing.T) {
+	setup(t)
+	key := trustTestKey(t, "current")
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return start }
+
+	for _, tt := range []struct {
+		name, window string
+		ok           bool
+	}{
+		{"valid", `"issued_at":"2025-06-01T11:00:00Z","expires_at":"2025-06-02T12:00:00Z"`, true},
+		{"expired", `"issued_at":"2025-05-01T11:00:00Z","expires_at":"2025-05-31T12:00:00Z"`, false},
+		{"within skew", `"expires_at":"2025-06-01T11:58:00Z"`, true},
+		{"future", `"issued_at":"2025-06-01T13:00:00Z"`, false},
+	} {
+		body := []byte(`{"version":1,"grpc":{"agent_origin":"https://agent.example.com"},` + tt.window + `}`)
+		_, err := parse(&document{Body: body, Signature: sign(t, key, body), KeyID: "current"})
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: err = %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+}
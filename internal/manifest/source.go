@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package manifest
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// EnvManifestURL overrides the manifest location, e.g. to target a staging or
+// self-hosted backend. The value is an http(s) URL or a local file path.
+const EnvManifestURL = "SEEDFAST_MANIFEST_URL"
+
+// DefaultURL is the manifest of the hosted Seedfast service.
+const DefaultURL = "https://seedfa.st/cli-endpoints.json"
+
+var (
+	sourceMu       sync.RWMutex
+	sourceOverride string
+)
+
+// SetSource sets the manifest location for this process (the --manifest flag),
+// taking precedence over SEEDFAST_MANIFEST_URL. An empty value removes the override.
+func SetSource(source string) {
+	sourceMu.Lock()
+	defer sourceMu.Unlock()
+	sourceOverride = strings.TrimSpace(source)
+}
+
+// Source returns the manifest location in effect: the SetSource override,
+// then SEEDFAST_MANIFEST_URL, then DefaultURL.
+func Source() string {
+	sourceMu.RLock()
+	override := sourceOverride
+	sourceMu.RUnlock()
+	if override != "" {
+		return override
+	}
+	if env := strings.TrimSpace(os.Getenv(EnvManifestURL)); env != "" {
+		return env
+	}
+	return DefaultURL
+}
+
+// isRemote reports whether a source is fetched over HTTP rather than read from disk.
+func isRemote(source string) bool {
+	lower := strings.ToLower(source)
+	return strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://")
+}
+
+// localPath returns the file path of a local source, accepting file:// URLs.
+func localPath(source string) string {
+	if strings.HasPrefix(strings.ToLower(source), "file://") {
+		return source[len("file://"):]
+	}
+	return source
+}
@@ -7,6 +7,7 @@ package manifest
 import (
 	"net/url"
 	"strings"
+	"time"
 )
 
 // Manifest represents the endpoint configuration from the server.
@@ -14,6 +15,10 @@ type Manifest struct {
 	Version int           `json:"version"`
 	GRPC    GRPCEndpoints `json:"grpc"`
 	HTTP    HTTPEndpoints `json:"http"`
+	// IssuedAt and ExpiresAt bound the validity of a signed manifest, so that an
+	// old manifest cannot be replayed indefinitely. Both are optional.
+	IssuedAt  time.Time `json:"issued_at,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
 }
 
 // GRPCEndpoints contains gRPC service addresses.
@@ -32,6 +37,9 @@ type HTTPEndpoints struct {
 	Health        string `json:"health"`         // e.g., "/api/health"
 	Version       string `json:"version"`        // e.g., "/api/version" (backend version)
 	CLIVersion    string `json:"cli_version"`    // e.g., "/api/cli/version" (latest CLI version)
+	// Optional; older backends do not report usage or session history
+	AccountUsage    string `json:"account_usage,omitempty"`    // e.g., "/api/cli/usage"
+	AccountSessions string `json:"account_sessions,omitempty"` // e.g., "/api/cli/sessions"
 }
 
 // HTTPBaseURL extracts the base URL from the gRPC agent address.
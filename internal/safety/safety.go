@@ -0,0 +1,242 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+// Package safety detects target databases that look like production systems
+// and gates write operations on them behind an explicit confirmation.
+//
+// The assessment combines cheap, offline signals (host and database name matched
+// against a deny-list pattern) with live signals read from the server: whether it
+// is a hot standby (pg_is_in_recovery), the total database size and the number of
+// rows already present in the tables proposed for seeding. Any signal marks the
+// target as production-like; the seed command then refuses to run write tasks
+// unless the user passes --i-know-this-is-production and types the database name.
+package safety
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"seedfast/cli/internal/dsn"
+	"seedfast/cli/internal/sqlexec"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DefaultDenyPattern matches host or database names that conventionally denote
+// production systems, e.g. "db.prod.internal", "orders_production", "live-primary".
+const DefaultDenyPattern = `(?i)(^|[^a-z0-9])(prod|production|live|primary|master)([^a-z0-9]|$)`
+
+// Default thresholds for live signals.
+const (
+	// DefaultMaxDatabaseBytes flags databases larger than 1 GiB.
+	DefaultMaxDatabaseBytes int64 = 1 << 30
+	// DefaultMaxTableRows flags planned tables that already hold more rows than this.
+	DefaultMaxTableRows int64 = 10000
+)
+
+// Policy holds the thresholds and patterns used to classify a target database.
+type Policy struct {
+	// DenyPattern is matched against the DSN host and database name.
+	DenyPattern *regexp.Regexp
+	// MaxDatabaseBytes is the database size above which the target is flagged (0 disables).
+	MaxDatabaseBytes int64
+	// MaxTableRows is the per-table row count above which the target is flagged (0 disables).
+	MaxTableRows int64
+}
+
+// DefaultPolicy returns the built-in policy.
+func DefaultPolicy() Policy {
+	return Policy{
+		DenyPattern:      regexp.MustCompile(DefaultDenyPattern),
+		MaxDatabaseBytes: DefaultMaxDatabaseBytes,
+		MaxTableRows:     DefaultMaxTableRows,
+	}
+}
+
+// WithDenyPattern returns a copy of the policy using the given regular expression.
+// An empty pattern keeps the current one.
+func (p Policy) WithDenyPattern(pattern string) (Policy, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return p, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return p, fmt.Errorf("invalid production pattern %q: %w", pattern, err)
+	}
+	p.DenyPattern = re
+	return p, nil
+}
+
+// Signal is a single reason why a target looks like production.
+type Signal struct {
+	// Check names the check that fired (e.g. "dsn_host", "in_recovery").
+	Check string
+	// Detail is a human-readable explanation.
+	Detail string
+	// Table is the table the signal is about, for per-table checks.
+	Table string
+}
+
+// Report collects the signals found for a target database.
+type Report struct {
+	Database string
+	Signals  []Signal
+}
+
+// Production reports whether any signal fired.
+func (r *Report) Production() bool {
+	return r != nil && len(r.Signals) > 0
+}
+
+func (r *Report) add(check, format string, args ...any) {
+	r.Signals = append(r.Signals, Signal{Check: check, Detail: fmt.Sprintf(format, args...)})
+}
+
+// addTable adds a per-table signal unless the same check already fired for
+// the table, e.g. on a previous plan.
+func (r *Report) addTable(check, table, format string, args ...any) {
+	for _, s := range r.Signals {
+		if s.Check == check && s.Table == table {
+			return
+		}
+	}
+	r.Signals = append(r.Signals, Signal{Check: check, Detail: fmt.Sprintf(format, args...), Table: table})
+}
+
+// CheckDSN evaluates offline signals from the connection string.
+func CheckDSN(policy Policy, rawDSN string) *Report {
+	r := &Report{}
+	info, err := dsn.ParseInfo(rawDSN)
+	if err != nil {
+		return r
+	}
+	r.Database = info.Database
+	if policy.DenyPattern == nil {
+		return r
+	}
+	if policy.DenyPattern.MatchString(info.Host) {
+		r.add("dsn_host", "host %q matches production pattern", info.Host)
+	}
+	if policy.DenyPattern.MatchString(info.Database) {
+		r.add("dsn_database", "database name %q matches production pattern", info.Database)
+	}
+	return r
+}
+
+// CheckServer adds live signals read from the server to the report.
+// Query failures are not treated as signals; the check is best-effort.
+func CheckServer(ctx context.Context, policy Policy, pool *pgxpool.Pool, r *Report) error {
+	var inRecovery bool
+	if err := pool.QueryRow(ctx, "SELECT pg_is_in_recovery()").Scan(&inRecovery); err != nil {
+		return err
+	}
+	if inRecovery {
+		r.add("in_recovery", "server is a hot standby (pg_is_in_recovery() = true)")
+	}
+
+	if policy.MaxDatabaseBytes > 0 {
+		var size int64
+		var pretty string
+		if err := pool.QueryRow(ctx, "SELECT pg_database_size(current_database()), pg_size_pretty(pg_database_size(current_database()))").Scan(&size, &pretty); err != nil {
+			return err
+		}
+		if size > policy.MaxDatabaseBytes {
+			r.add("database_size", "database size is %s", pretty)
+		}
+	}
+	return nil
+}
+
+// CheckTables adds a signal for every planned table that already holds more
+// rows than the policy allows. Counting is bounded so huge tables stay cheap.
+func CheckTables(ctx context.Context, policy Policy, pool *pgxpool.Pool, tables []string, r *Report) error {
+	count := func(table string, limit int64) (int64, error) {
+		var n int64
+		q := "SELECT count(*) FROM (SELECT 1 FROM " + sqlexec.QuoteTableName(table) + " LIMIT $1) t"
+		err := pool.QueryRow(ctx, q, limit).Scan(&n)
+		return n, err
+	}
+	checkTableRows(policy, tables, count, r)
+	return nil
+}
+
+// checkTableRows applies the row threshold using count, which returns the
+// rows in a table up to limit.
+func checkTableRows(policy Policy, tables []string, count func(table string, limit int64) (int64, error), r *Report) {
+	if policy.MaxTableRows <= 0 {
+		return
+	}
+	for _, t := range tables {
+		n, err := count(t, policy.MaxTableRows+1)
+		if err != nil {
+			// Table may not exist yet; skip it
+			continue
+		}
+		if n > policy.MaxTableRows {
+			r.addTable("table_rows", t, "table %s already has more than %d rows", t, policy.MaxTableRows)
+		}
+	}
+}
+
+// Guard gates write tasks on a production-like target until the user confirms.
+// It is safe for concurrent use by seeding workers.
+type Guard struct {
+	mu        sync.RWMutex
+	report    *Report
+	confirmed bool
+}
+
+// NewGuard creates a guard for the given report.
+func NewGuard(r *Report) *Guard {
+	if r == nil {
+		r = &Report{}
+	}
+	return &Guard{report: r}
+}
+
+// Report returns the report the guard is evaluating.
+func (g *Guard) Report() *Report {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.report
+}
+
+// Update merges additional signals into the guard's report.
+// A confirmation given before new signals appear stays valid only if it was
+// given for a production target; new signals on a previously clean target
+// require a fresh confirmation.
+func (g *Guard) Update(fn func(r *Report)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	wasProduction := g.report.Production()
+	fn(g.report)
+	if !wasProduction && g.report.Production() {
+		g.confirmed = false
+	}
+}
+
+// Confirm marks the production target as explicitly confirmed by the user.
+func (g *Guard) Confirm() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.confirmed = true
+}
+
+// NeedsConfirmation reports whether the target looks like production and the
+// user has not confirmed it yet.
+func (g *Guard) NeedsConfirmation() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.report.Production() && !g.confirmed
+}
+
+// AllowWrite returns an error when write tasks must not be executed.
+func (g *Guard) AllowWrite() error {
+	if g == nil || !g.NeedsConfirmation() {
+		return nil
+	}
+	return fmt.Errorf("write blocked by production safeguard: target database %q looks like production and was not confirmed", g.Report().Database)
+}
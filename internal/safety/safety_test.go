@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package safety
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckDSN(t *testing.T) {
+	tests := []struct {
+		dsn    string
+		checks []string
+	}{
+		{"postgres://u:p@localhost:5432/app", nil},
+		{"postgres://u:p@db.prod.internal:5432/app", []string{"dsn_host"}},
+		{"postgres://u:p@localhost:5432/orders_production", []string{"dsn_database"}},
+		{"postgres://u:p@live-primary:5432/prod", []string{"dsn_host", "dsn_database"}},
+		{"postgres://u:p@reproduce:5432/productivity", nil},
+	}
+	for _, tt := range tests {
+		r := CheckDSN(DefaultPolicy(), tt.dsn)
+		if len(r.Signals) != len(tt.checks) {
+			t.Errorf("CheckDSN(%q) signals = %v, want checks %v", tt.dsn, r.Signals, tt.checks)
+			continue
+		}
+		for i, s := range r.Signals {
+			if s.Check != tt.checks[i] {
+				t.Errorf("CheckDSN(%q) signal %d = %q, want %q", tt.dsn, i, s.Check, tt.checks[i])
+			}
+		}
+	}
+
+	policy, err := DefaultPolicy().WithDenyPattern(`(?i)^staging$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := CheckDSN(policy, "postgres://u:p@db.prod.internal:5432/staging"); len(r.Signals) != 1 || r.Signals[0].Check != "dsn_database" {
+		t.Errorf("custom pattern signals = %v, want dsn_database only", r.Signals)
+	}
+	if _, err := DefaultPolicy().WithDenyPattern("("); err == nil {
+		t.Error("WithDenyPattern accepted an invalid pattern")
+	}
+}
+
+func TestCheckTableRows(t *testing.T) {
+	rows := map[string]int64{"users": 10001, "orders": 10000, "audit": 50000}
+	count := func(table string, limit int64) (int64, error) {
+		n, ok := rows[table]
+		if !ok {
+			return 0, errors.New("relation does not exist")
+		}
+		return min(n, limit), nil
+	}
+	policy := DefaultPolicy()
+	r := &Report{}
+	checkTableRows(policy, []string{"users", "orders", "missing"}, count, r)
+	if len(r.Signals) != 1 || r.Signals[0].Table != "users" {
+		t.Fatalf("signals = %v, want table_rows for users", r.Signals)
+	}
+
+	// A re-plan must not stack duplicate signals
+	checkTableRows(policy, []string{"users", "audit"}, count, r)
+	if len(r.Signals) != 2 || r.Signals[1].Table != "audit" {
+		t.Errorf("signals after re-plan = %v, want users and audit once", r.Signals)
+	}
+
+	policy.MaxTableRows = 0
+	r = &Report{}
+	checkTableRows(policy, []string{"audit"}, count, r)
+	if r.Production() {
+		t.Errorf("disabled threshold produced signals %v", r.Signals)
+	}
+}
+
+func TestGuardUpdate(t *testing.T) {
+	// Clean target: writes allowed until a signal appears, then a fresh confirmation is needed
+	g := NewGuard(nil)
+	g.Confirm()
+	if err := g.AllowWrite(); err != nil {
+		t.Fatalf("clean target blocked: %v", err)
+	}
+	g.Update(func(r *Report) { r.add("table_rows", "table users has rows") })
+	if !g.NeedsConfirmation() {
+		t.Fatal("new signal on a clean target did not require confirmation")
+	}
+	if g.AllowWrite() == nil {
+		t.Fatal("unconfirmed production target allowed writes")
+	}
+	g.Confirm()
+	if err := g.AllowWrite(); err != nil {
+		t.Fatalf("confirmed target blocked: %v", err)
+	}
+
+	// Production target confirmed up front stays confirmed when more signals appear
+	g = NewGuard(&Report{Signals: []Signal{{Check: "dsn_host"}}})
+	g.Confirm()
+	g.Update(func(r *Report) { r.add("in_recovery", "hot standby") })
+	if g.NeedsConfirmation() {
+		t.Error("confirmation for a production target was reset by new signals")
+	}
+
+	var nilGuard *Guard
+	if err := nilGuard.AllowWrite(); err != nil {
+		t.Errorf("nil guard blocked writes: %v", err)
+	}
+}
@@ -29,6 +29,9 @@ const (
 	BackendEventWorkflowCompleted BackendEventType = "workflow_completed"
 	BackendEventStreamClosed      BackendEventType = "stream_closed"
 	BackendEventStreamError       BackendEventType = "stream_error"
+	// BackendEventSessionAccepted carries the options negotiation result as a
+	// JSON-encoded model.Negotiation; older servers never send it
+	BackendEventSessionAccepted BackendEventType = "session_accepted"
 )
 
 // ResponseSender is a function that sends responses back to the backend.
@@ -0,0 +1,280 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+// Package snapshot saves the contents of a set of tables to local files before a
+// seeding run and restores them afterwards.
+//
+// Each snapshot lives in its own directory under <user dir>/snapshots/<id> and
+// consists of a snapshot.json manifest plus one COPY (binary format) file per
+// table. Tables are exported inside a single REPEATABLE READ transaction so the
+// snapshot is consistent across tables. Restore truncates the tables and loads
+// them back in foreign-key order (parents first) in one transaction.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"seedfast/cli/internal/sqlexec"
+	"seedfast/cli/internal/userdir"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// manifestFile is the name of the metadata file inside a snapshot directory.
+const manifestFile = "snapshot.json"
+
+// Table describes one table captured in a snapshot.
+type Table struct {
+	// Name is the schema-qualified table name
+	Name string `json:"name"`
+	// File is the COPY data file name relative to the snapshot directory
+	File string `json:"file"`
+	// Bytes is the size of the exported data
+	Bytes int64 `json:"bytes"`
+}
+
+// Snapshot is the metadata stored alongside the exported table data.
+type Snapshot struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Database  string    `json:"database"`
+	// DSN is the masked connection string; restore requires the same host, port and database
+	DSN    string  `json:"dsn"`
+	Tables []Table `json:"tables"`
+}
+
+// Dir returns the directory holding all snapshots, creating it if needed.
+func Dir() (string, error) {
+	return userdir.Sub("snapshots")
+}
+
+// path returns the directory of a single snapshot.
+func path(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return "", fmt.Errorf("invalid snapshot id %q", id)
+	}
+	base, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, id), nil
+}
+
+// Create exports the given tables to a new snapshot with the given id.
+// Unqualified table names are resolved with the connection's search_path.
+// Nothing is left on disk when it fails.
+func Create(ctx context.Context, pool *pgxpool.Pool, id, database, maskedDSN string, tables []string) (_ *Snapshot, err error) {
+	if len(tables) == 0 {
+		return nil, errors.New("no tables to snapshot")
+	}
+	dir, err := path(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create snapshot directory: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(dir)
+		}
+	}()
+
+	snap := &Snapshot{ID: id, CreatedAt: time.Now().UTC(), Database: database, DSN: maskedDSN}
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	tx, err := conn.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	seen := make(map[string]bool)
+	for _, t := range tables {
+		name, err := resolveTable(ctx, tx, t)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot %s: %w", t, err)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		file := name + ".copy"
+		f, err := os.OpenFile(filepath.Join(dir, file), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.Conn().PgConn().CopyTo(ctx, f, "COPY "+sqlexec.QuoteTableName(name)+" TO STDOUT (FORMAT binary)")
+		st, _ := f.Stat()
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("snapshot %s: %w", name, err)
+		}
+		tbl := Table{Name: name, File: file}
+		if st != nil {
+			tbl.Bytes = st.Size()
+		}
+		snap.Tables = append(snap.Tables, tbl)
+	}
+
+	b, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, manifestFile), b, 0o600); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// resolveTable returns the schema-qualified name of a table as the server
+// resolves it, so unqualified names follow the search_path rather than
+// defaulting to public.
+func resolveTable(ctx context.Context, tx pgx.Tx, table string) (string, error) {
+	if strings.Contains(table, ".") {
+		return sqlexec.QualifyTableName(strings.ReplaceAll(table, `"`, "")), nil
+	}
+	var name string
+	err := tx.QueryRow(ctx, `
+		SELECT n.nspname || '.' || c.relname
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.oid = to_regclass($1)`, sqlexec.QuoteTableName(table)).Scan(&name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("table %q does not exist", table)
+	}
+	return name, err
+}
+
+// Load reads the metadata of a snapshot.
+func Load(id string) (*Snapshot, error) {
+	dir, err := path(id)
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("snapshot %q not found", id)
+		}
+		return nil, err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(b, &snap); err != nil {
+		return nil, fmt.Errorf("read snapshot %q: %w", id, err)
+	}
+	return &snap, nil
+}
+
+// List returns all snapshots, newest first.
+func List() ([]*Snapshot, error) {
+	base, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		return nil, err
+	}
+	var out []*Snapshot
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if snap, err := Load(e.Name()); err == nil {
+			out = append(out, snap)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+// Remove deletes a snapshot and its data files.
+func Remove(id string) error {
+	dir, err := path(id)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// RestoreOrder returns the snapshot tables in foreign-key-safe load order.
+func RestoreOrder(ctx context.Context, pool *pgxpool.Pool, snap *Snapshot) ([]string, error) {
+	names := make([]string, 0, len(snap.Tables))
+	for _, t := range snap.Tables {
+		names = append(names, t.Name)
+	}
+	fks, err := sqlexec.NewSchemaInspector(pool).GetForeignKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sqlexec.DependencyOrder(names, fks), nil
+}
+
+// Restore truncates the snapshot tables and loads the saved data back in a
+// single transaction. Identity columns keep their current sequence values.
+func Restore(ctx context.Context, pool *pgxpool.Pool, snap *Snapshot) error {
+	dir, err := path(snap.ID)
+	if err != nil {
+		return err
+	}
+	order, err := RestoreOrder(ctx, pool, snap)
+	if err != nil {
+		return err
+	}
+	files := make(map[string]string, len(snap.Tables))
+	for _, t := range snap.Tables {
+		files[t.Name] = t.File
+	}
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	quoted := make([]string, len(order))
+	for i, name := range order {
+		quoted[i] = sqlexec.QuoteTableName(name)
+	}
+	// A single TRUNCATE covers foreign keys between the restored tables;
+	// references from other tables make it fail instead of cascading.
+	if _, err := tx.Exec(ctx, "TRUNCATE "+strings.Join(quoted, ", ")); err != nil {
+		return fmt.Errorf("truncate: %w", err)
+	}
+
+	for i, name := range order {
+		f, err := os.Open(filepath.Join(dir, files[name]))
+		if err != nil {
+			return fmt.Errorf("restore %s: %w", name, err)
+		}
+		_, err = tx.Conn().PgConn().CopyFrom(ctx, f, "COPY "+quoted[i]+" FROM STDIN (FORMAT binary)")
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("restore %s: %w", name, err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
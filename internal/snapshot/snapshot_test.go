@@ -0,0 +1,131 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func writeManifest(t *testing.T, snap *Snapshot) {
+	t.Helper()
+	dir, err := path(snap.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := json.Marshal(snap)
+	if err := os.WriteFile(filepath.Join(dir, manifestFile), b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadListRemove(t *testing.T) {
+	t.Setenv("SEEDFAST_CONFIG_DIR", t.TempDir())
+	now := time.Now().UTC()
+	writeManifest(t, &Snapshot{ID: "old", CreatedAt: now.Add(-time.Hour), Database: "app"})
+	writeManifest(t, &Snapshot{ID: "new", CreatedAt: now, Database: "app"})
+
+	list, err := List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].ID != "new" || list[1].ID != "old" {
+		t.Fatalf("List() = %v, want new, old", list)
+	}
+	if err := Remove("old"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load("old"); err == nil {
+		t.Error("Load succeeded after Remove")
+	}
+	for _, id := range []string{"", ".", "..", "a/b", `a\b`} {
+		if _, err := Load(id); err == nil {
+			t.Errorf("Load(%q) accepted an invalid id", id)
+		}
+	}
+}
+
+func TestCreateRemovesDirectoryOnError(t *testing.T) {
+	t.Setenv("SEEDFAST_CONFIG_DIR", t.TempDir())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// Nothing listens on port 1, so acquiring a connection fails
+	pool, err := pgxpool.New(ctx, "postgres://seedfast@127.0.0.1:1/app?connect_timeout=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	if _, err := Create(ctx, pool, "failed", "app", "", []string{"users"}); err == nil {
+		t.Fatal("Create succeeded without a database")
+	}
+	dir, _ := path("failed")
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("snapshot directory left behind: %v", err)
+	}
+}
+
+// TestCreateRestore runs against the database in SEEDFAST_TEST_DATABASE_URL.
+func TestCreateRestore(t *testing.T) {
+	url := os.Getenv("SEEDFAST_TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("SEEDFAST_TEST_DATABASE_URL not set")
+	}
+	t.Setenv("SEEDFAST_CONFIG_DIR", t.TempDir())
+	ctx := context.Background()
+	cfg, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ConnConfig.RuntimeParams["search_path"] = "seedfast_snapshot_test"
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	exec := func(sql string) {
+		t.Helper()
+		if _, err := pool.Exec(ctx, sql); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exec("DROP SCHEMA IF EXISTS seedfast_snapshot_test CASCADE")
+	exec("CREATE SCHEMA seedfast_snapshot_test")
+	defer pool.Exec(ctx, "DROP SCHEMA IF EXISTS seedfast_snapshot_test CASCADE")
+	exec("CREATE TABLE seedfast_snapshot_test.users (id int PRIMARY KEY)")
+	exec("CREATE TABLE seedfast_snapshot_test.orders (id int PRIMARY KEY, user_id int REFERENCES seedfast_snapshot_test.users)")
+	exec("INSERT INTO seedfast_snapshot_test.users VALUES (1), (2)")
+	exec("INSERT INTO seedfast_snapshot_test.orders VALUES (10, 1)")
+
+	snap, err := Create(ctx, pool, "roundtrip", "app", "", []string{"orders", "users"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Tables[0].Name != "seedfast_snapshot_test.orders" {
+		t.Fatalf("unqualified table resolved to %q, want the search_path schema", snap.Tables[0].Name)
+	}
+
+	exec("INSERT INTO seedfast_snapshot_test.users VALUES (3)")
+	exec("INSERT INTO seedfast_snapshot_test.orders VALUES (11, 3)")
+	if err := Restore(ctx, pool, snap); err != nil {
+		t.Fatal(err)
+	}
+	var users, orders int
+	if err := pool.QueryRow(ctx, "SELECT (SELECT count(*) FROM seedfast_snapshot_test.users), (SELECT count(*) FROM seedfast_snapshot_test.orders)").Scan(&users, &orders); err != nil {
+		t.Fatal(err)
+	}
+	if users != 2 || orders != 1 {
+		t.Errorf("after restore users = %d, orders = %d, want 2 and 1", users, orders)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package sqlexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ForeignKey describes a single table-level foreign key dependency.
+// Both names are schema-qualified ("schema.table").
+type ForeignKey struct {
+	// Table is the referencing (child) table
+	Table string
+	// RefTable is the referenced (parent) table
+	RefTable string
+}
+
+// GetForeignKeys returns all foreign key dependencies between user tables.
+// System schemas (pg_catalog, information_schema, pg_toast) are excluded.
+func (si *SchemaInspector) GetForeignKeys(ctx context.Context) ([]ForeignKey, error) {
+	fkQuery := `
+		SELECT DISTINCT cn.nspname || '.' || c.relname, rn.nspname || '.' || r.relname
+		FROM pg_constraint con
+		JOIN pg_class c ON c.oid = con.conrelid
+		JOIN pg_namespace cn ON cn.oid = c.relnamespace
+		JOIN pg_class r ON r.oid = con.confrelid
+		JOIN pg_namespace rn ON rn.oid = r.relnamespace
+		WHERE con.contype = 'f'
+		  AND cn.nspname NOT IN ('pg_catalog', 'information_schema')
+		  AND cn.nspname NOT LIKE 'pg_toast%'`
+
+	rows, err := si.pool.Query(ctx, fkQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fks []ForeignKey
+	for rows.Next() {
+		var fk ForeignKey
+		if err := rows.Scan(&fk.Table, &fk.RefTable); err == nil {
+			fks = append(fks, fk)
+		}
+	}
+	return fks, rows.Err()
+}
+
+// ResolveTables returns the schema-qualified names of the given tables as the
+// server resolves them, so unqualified names follow the connection's
+// search_path. It fails if any of the tables does not exist.
+func (si *SchemaInspector) ResolveTables(ctx context.Context, tables []string) ([]string, error) {
+	out := make([]string, 0, len(tables))
+	var missing []string
+	for _, t := range tables {
+		var name string
+		err := si.pool.QueryRow(ctx, `
+			SELECT n.nspname || '.' || c.relname
+			FROM pg_class c
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			WHERE c.oid = to_regclass($1)`, QuoteTableName(t)).Scan(&name)
+		if errors.Is(err, pgx.ErrNoRows) {
+			missing = append(missing, t)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, name)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("tables do not exist: %s", strings.Join(missing, ", "))
+	}
+	return out, nil
+}
+
+// QualifyTableName returns the "schema.table" form of a table name,
+// defaulting to the public schema when none is given.
+func QualifyTableName(tableName string) string {
+	schema, table := parseTableName(tableName)
+	return schema + "." + table
+}
+
+// QualifyTableNameIn is QualifyTableName for a statement run with the given
+// search_path: unqualified names resolve to its first schema instead of public.
+func QualifyTableNameIn(tableName, searchPath string) string {
+	if strings.Contains(tableName, ".") {
+		return QualifyTableName(tableName)
+	}
+	first, _, _ := strings.Cut(searchPath, ",")
+	first = strings.Trim(strings.TrimSpace(first), `"`)
+	if first == "" {
+		return QualifyTableName(tableName)
+	}
+	return first + "." + tableName
+}
+
+// QuoteTableName quotes a "schema.table" or "table" name as a SQL identifier,
+// stripping any quotes already present around the parts.
+func QuoteTableName(tableName string) string {
+	parts := strings.SplitN(tableName, ".", 2)
+	for i := range parts {
+		parts[i] = strings.Trim(parts[i], `"`)
+	}
+	return pgx.Identifier(parts).Sanitize()
+}
+
+// DependencyOrder sorts tables so that referenced (parent) tables come before
+// the tables referencing them. Only dependencies within the given set are
+// considered; self-references are ignored. Tables that are part of a cycle are
+// appended in their original order. Returned names are schema-qualified.
+func DependencyOrder(tables []string, fks []ForeignKey) []string {
+	index := make(map[string]int, len(tables))
+	var names []string
+	for _, t := range tables {
+		q := QualifyTableName(t)
+		if _, ok := index[q]; ok {
+			continue
+		}
+		index[q] = len(names)
+		names = append(names, q)
+	}
+
+	indegree := make(map[string]int, len(names))
+	children := make(map[string][]string)
+	seen := make(map[ForeignKey]struct{})
+	for _, fk := range fks {
+		if fk.Table == fk.RefTable {
+			continue
+		}
+		if _, ok := index[fk.Table]; !ok {
+			continue
+		}
+		if _, ok := index[fk.RefTable]; !ok {
+			continue
+		}
+		if _, dup := seen[fk]; dup {
+			continue
+		}
+		seen[fk] = struct{}{}
+		indegree[fk.Table]++
+		children[fk.RefTable] = append(children[fk.RefTable], fk.Table)
+	}
+
+	// Kahn's algorithm, keeping the input order stable among ready tables
+	var ready []string
+	for _, n := range names {
+		if indegree[n] == 0 {
+			ready = append(ready, n)
+		}
+	}
+	ordered := make([]string, 0, len(names))
+	done := make(map[string]bool, len(names))
+	for len(ready) > 0 {
+		n := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, n)
+		done[n] = true
+		next := children[n]
+		sort.SliceStable(next, func(i, j int) bool { return index[next[i]] < index[next[j]] })
+		for _, c := range next {
+			indegree[c]--
+			if indegree[c] == 0 {
+				ready = append(ready, c)
+			}
+		}
+	}
+	for _, n := range names {
+		if !done[n] {
+			ordered = append(ordered, n)
+		}
+	}
+	return ordered
+}
+
+// DependentTables returns the tables outside the given set that reference it,
+// directly or transitively. These are the tables a TRUNCATE ... CASCADE would
+// also empty. Returned names are schema-qualified and sorted.
+func DependentTables(tables []string, fks []ForeignKey) []string {
+	inSet := make(map[string]bool, len(tables))
+	for _, t := range tables {
+		inSet[QualifyTableName(t)] = true
+	}
+	referencedBy := make(map[string][]string)
+	for _, fk := range fks {
+		referencedBy[fk.RefTable] = append(referencedBy[fk.RefTable], fk.Table)
+	}
+
+	visited := make(map[string]bool)
+	var queue []string
+	for t := range inSet {
+		queue = append(queue, t)
+		visited[t] = true
+	}
+	var out []string
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+		for _, child := range referencedBy[t] {
+			if visited[child] {
+				continue
+			}
+			visited[child] = true
+			out = append(out, child)
+			queue = append(queue, child)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package sqlexec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDependencyOrder(t *testing.T) {
+	fks := []ForeignKey{
+		{Table: "public.orders", RefTable: "public.users"},
+		{Table: "public.order_items", RefTable: "public.orders"},
+		{Table: "public.order_items", RefTable: "public.products"},
+		{Table: "public.users", RefTable: "public.users"},      // self-reference
+		{Table: "billing.invoices", RefTable: "public.orders"}, // outside the set
+	}
+
+	tests := []struct {
+		name   string
+		tables []string
+		want   []string
+	}{
+		{
+			name:   "parents before children",
+			tables: []string{"order_items", "orders", "products", "users"},
+			want:   []string{"public.products", "public.users", "public.orders", "public.order_items"},
+		},
+		{
+			name:   "unqualified and qualified names are deduplicated",
+			tables: []string{"public.orders", "orders", "users"},
+			want:   []string{"public.users", "public.orders"},
+		},
+		{
+			name:   "cycle keeps input order",
+			tables: []string{"a", "b"},
+			want:   []string{"public.a", "public.b"},
+		},
+	}
+	cyclic := append(fks, ForeignKey{Table: "public.a", RefTable: "public.b"}, ForeignKey{Table: "public.b", RefTable: "public.a"})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DependencyOrder(tt.tables, cyclic); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DependencyOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDependentTables(t *testing.T) {
+	fks := []ForeignKey{
+		{Table: "public.orders", RefTable: "public.users"},
+		{Table: "public.order_items", RefTable: "public.orders"},
+		{Table: "billing.invoices", RefTable: "public.orders"},
+		{Table: "public.audit", RefTable: "public.products"},
+	}
+	got := DependentTables([]string{"users"}, fks)
+	want := []string{"billing.invoices", "public.order_items", "public.orders"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DependentTables() = %v, want %v", got, want)
+	}
+}
+
+func TestQualifyTableNameIn(t *testing.T) {
+	tests := []struct {
+		name, searchPath, want string
+	}{
+		{"users", "", "public.users"},
+		{"users", "billing", "billing.users"},
+		{"users", `"billing", public`, "billing.users"},
+		{"audit.events", "billing", "audit.events"},
+	}
+	for _, tt := range tests {
+		if got := QualifyTableNameIn(tt.name, tt.searchPath); got != tt.want {
+			t.Errorf("QualifyTableNameIn(%q, %q) = %q, want %q", tt.name, tt.searchPath, got, tt.want)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 // Copyright (c) 2025 Seedfast
 // Licensed under the MIT License. See LICENSE file in the project root for details.
 
-// Package sqlexec provides a concurrent SQL execution engine over a pgx connection pool.
+// Package sqlexec provides a concurrent SQL execution engine for database seeding.
+// The Executor interface is implemented per dialect and registered by database type
+// (see Register and Open); PostgresExecutor runs over a pgx connection pool.
 // It handles SQL statement execution, result formatting for database seeding operations.
 // The package supports both read and write operations with automatic transaction management and
 // provides JSON-formatted results suitable for backend communication.
@@ -18,7 +20,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -95,40 +101,110 @@ func (r Result) MarshalJSON() ([]byte, error) {
 	return jsonBytes, nil
 }
 
-// Executor executes SQL statements using a connection pool.
+// RowTracker receives the primary keys of rows inserted by write statements.
+// Keys are text-encoded, one slice per row in columns order.
+type RowTracker interface {
+	Track(table string, columns []string, keys [][]string) error
+}
+
+// Executor runs SQL tasks received from the backend against one database.
+// It is implemented by PostgresExecutor and by the executors of other dialects,
+// such as internal/sqlexec/mysql; see Register and Open.
+type Executor interface {
+	// ExecuteSQLInSchema runs a statement and returns a JSON-encoded Result
+	ExecuteSQLInSchema(ctx context.Context, sql string, isWrite bool, schema string) (string, error)
+	// GetSchemaInfo returns cached table metadata
+	GetSchemaInfo(ctx context.Context, tableName string) (*SchemaInfo, error)
+	// Close releases the database connections
+	Close() error
+}
+
+var _ Executor = (*PostgresExecutor)(nil)
+
+// Versioner is implemented by executors that can report the version of the
+// database server they are connected to.
+type Versioner interface {
+	ServerVersion(ctx context.Context) (string, error)
+}
+
+// ServerVersion returns the database server version reported by e, or "" when
+// e cannot report it.
+func ServerVersion(ctx context.Context, e Executor) string {
+	v, ok := e.(Versioner)
+	if !ok {
+		return ""
+	}
+	version, err := v.ServerVersion(ctx)
+	if err != nil {
+		return ""
+	}
+	return version
+}
+
+// PostgresExecutor executes SQL statements using a pgx connection pool.
 // It integrates schema inspection and SQL fixing capabilities for robust seeding operations.
-type Executor struct {
+type PostgresExecutor struct {
 	// Pool is the PostgreSQL connection pool
 	Pool *pgxpool.Pool
 	// inspector provides schema metadata caching and introspection
 	inspector *SchemaInspector
 	// fixer applies SQL statement repairs based on schema constraints
 	fixer *SQLFixer
+	// Tracker, when set, receives the primary keys of inserted rows
+	Tracker RowTracker
 }
 
-// New creates an Executor from an existing pgx pool.
+// New creates a PostgresExecutor from an existing pgx pool.
 // It initializes the schema inspector and SQL fixer for enhanced seeding capabilities.
-func New(pool *pgxpool.Pool) *Executor {
+func New(pool *pgxpool.Pool) *PostgresExecutor {
 	inspector := NewSchemaInspector(pool)
 	fixer := NewSQLFixer(inspector)
-	return &Executor{
+	return &PostgresExecutor{
 		Pool:      pool,
 		inspector: inspector,
 		fixer:     fixer,
 	}
 }
 
+// openPostgres connects a pool to a PostgreSQL DSN. Connections are established
+// lazily, so errors are limited to invalid DSNs.
+func openPostgres(ctx context.Context, dsn string) (Executor, error) {
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+	return New(pool), nil
+}
+
+// Close closes the connection pool.
+func (e *PostgresExecutor) Close() error {
+	e.Pool.Close()
+	return nil
+}
+
+// GetSchemaInfo returns primary key, sequence and check constraint metadata for a table.
+func (e *PostgresExecutor) GetSchemaInfo(ctx context.Context, tableName string) (*SchemaInfo, error) {
+	return e.inspector.GetSchemaInfo(ctx, tableName)
+}
+
 // ExecuteSQL runs an arbitrary SQL statement and returns a JSON payload.
 // Read queries return {columns, rows}. Write operations return {} or {error}.
-func (e *Executor) ExecuteSQL(ctx context.Context, sql string, isWrite bool) (string, error) {
+func (e *PostgresExecutor) ExecuteSQL(ctx context.Context, sql string, isWrite bool) (string, error) {
 	return e.ExecuteSQLInSchema(ctx, sql, isWrite, "")
 }
 
+// ServerVersion returns the server_version setting, e.g. "16.3".
+func (e *PostgresExecutor) ServerVersion(ctx context.Context) (string, error) {
+	var v string
+	err := e.Pool.QueryRow(ctx, "SHOW server_version").Scan(&v)
+	return v, err
+}
+
 // ExecuteSQLInSchema runs SQL with optional schema by setting search_path.
 // The schema parameter is optional and only used for backward compatibility.
 // In most cases, SQL statements should use schema-qualified table names (e.g., "app.users")
 // which PostgreSQL handles natively without needing to set search_path.
-func (e *Executor) ExecuteSQLInSchema(ctx context.Context, sql string, isWrite bool, schema string) (string, error) {
+func (e *PostgresExecutor) ExecuteSQLInSchema(ctx context.Context, sql string, isWrite bool, schema string) (string, error) {
 	// Fix common seeding issues before execution using schema-aware approach
 	fixedSQL, err := e.fixer.FixSeedingSQL(ctx, sql)
 	if err != nil {
@@ -140,6 +216,14 @@ func (e *Executor) ExecuteSQLInSchema(ctx context.Context, sql string, isWrite b
 		logDebug("SQL was modified for seeding fixes using schema-aware approach")
 		sql = fixedSQL
 	}
+	// Resolve insert tracking before acquiring a connection; schema inspection
+	// needs one of its own
+	var trackTable string
+	var trackCols []string
+	track := false
+	if isWrite {
+		trackTable, trackCols, track = e.trackableInsert(ctx, sql, schema)
+	}
 	res := Result{
 		Columns: []string{},
 		Rows:    [][]any{},
@@ -177,7 +261,13 @@ func (e *Executor) ExecuteSQLInSchema(ctx context.Context, sql string, isWrite b
 		}
 		defer tx.Rollback(ctx) // Rollback if commit doesn't happen
 
-		ct, err := tx.Exec(ctx, sql)
+		var ct pgconn.CommandTag
+		var trackKeys [][]string
+		if track {
+			ct, trackKeys, err = execTracked(ctx, tx, sql, trackCols)
+		} else {
+			ct, err = tx.Exec(ctx, sql)
+		}
 		if err != nil {
 			logDebug("Exec failed: %v", err)
 			res.Error = err.Error()
@@ -197,6 +287,12 @@ func (e *Executor) ExecuteSQLInSchema(ctx context.Context, sql string, isWrite b
 		}
 
 		logDebug("COMMIT succeeded!")
+		// Keys are recorded only once the rows exist; undo deletes by these keys
+		if track {
+			if err := e.Tracker.Track(trackTable, trackCols, trackKeys); err != nil {
+				logDebug("Failed to track inserted keys for %s: %v", trackTable, err)
+			}
+		}
 		jsonBytes, _ := res.MarshalJSON()
 		return string(jsonBytes), nil
 	}
@@ -247,3 +343,93 @@ func (e *Executor) ExecuteSQLInSchema(ctx context.Context, sql string, isWrite b
 
 	return jsonStr, nil
 }
+
+var (
+	insertTableRegex = regexp.MustCompile(`(?i)^\s*INSERT\s+INTO\s+([^\s(]+)`)
+	returningRegex   = regexp.MustCompile(`(?i)\bRETURNING\b`)
+	doUpdateRegex    = regexp.MustCompile(`(?i)\bDO\s+UPDATE\b`)
+	writeTargetRegex = regexp.MustCompile(`(?i)^\s*(?:INSERT\s+INTO|UPDATE|DELETE\s+FROM|TRUNCATE(?:\s+TABLE)?|COPY)\s+(?:ONLY\s+)?([^\s(;,]+)`)
+)
+
+// WriteTarget returns the table written by a single INSERT, UPDATE, DELETE,
+// TRUNCATE or COPY statement, without quotes and as written (possibly
+// unqualified). It returns "" for other statements, e.g. those starting with WITH.
+func WriteTarget(sql string) string {
+	match := writeTargetRegex.FindStringSubmatch(sql)
+	if match == nil {
+		return ""
+	}
+	return strings.ReplaceAll(match[1], `"`, "")
+}
+
+// trackableInsert reports whether the primary keys of rows inserted by sql can
+// be captured with an injected RETURNING clause. Only single INSERT statements
+// into tables with a primary key qualify; upserts are skipped because they may
+// return rows that already existed. Unqualified tables resolve to the first
+// schema of searchPath, which the statement runs with.
+func (e *PostgresExecutor) trackableInsert(ctx context.Context, sql, searchPath string) (table string, keyCols []string, ok bool) {
+	if e.Tracker == nil {
+		return "", nil, false
+	}
+	match := insertTableRegex.FindStringSubmatch(sql)
+	if match == nil {
+		return "", nil, false
+	}
+	if _, ok := returningBody(sql); !ok {
+		return "", nil, false
+	}
+	table = QualifyTableNameIn(strings.ReplaceAll(match[1], `"`, ""), searchPath)
+	info, err := e.inspector.GetSchemaInfo(ctx, table)
+	if err != nil || len(info.PrimaryKeyCols) == 0 {
+		return "", nil, false
+	}
+	return table, info.PrimaryKeyCols, true
+}
+
+// returningBody returns sql without its trailing semicolon, and whether a
+// RETURNING clause can be appended to it. Statements that already return rows,
+// upserts, multiple statements and statements ending in a line comment, which
+// would swallow the clause, do not qualify.
+func returningBody(sql string) (string, bool) {
+	body := strings.TrimRight(strings.TrimSpace(sql), "; \t\r\n")
+	if strings.Contains(body, ";") || returningRegex.MatchString(body) || doUpdateRegex.MatchString(body) {
+		return "", false
+	}
+	lastLine := body[strings.LastIndex(body, "\n")+1:]
+	if strings.Contains(lastLine, "--") {
+		return "", false
+	}
+	return body, true
+}
+
+// execTracked runs an INSERT with a RETURNING clause for the primary key columns
+// and returns the inserted keys. The caller records them after the commit.
+func execTracked(ctx context.Context, tx pgx.Tx, sql string, keyCols []string) (pgconn.CommandTag, [][]string, error) {
+	returning := make([]string, len(keyCols))
+	for i, c := range keyCols {
+		returning[i] = pgx.Identifier{c}.Sanitize() + "::text"
+	}
+	body, _ := returningBody(sql)
+	rows, err := tx.Query(ctx, body+" RETURNING "+strings.Join(returning, ", "))
+	if err != nil {
+		return pgconn.CommandTag{}, nil, err
+	}
+	var keys [][]string
+	for rows.Next() {
+		key := make([]string, len(keyCols))
+		dest := make([]any, len(keyCols))
+		for i := range key {
+			dest[i] = &key[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			rows.Close()
+			return pgconn.CommandTag{}, nil, err
+		}
+		keys = append(keys, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return pgconn.CommandTag{}, nil, err
+	}
+	return rows.CommandTag(), keys, nil
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package sqlexec
+
+import "testing"
+
+func TestWriteTarget(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{`INSERT INTO users (id) VALUES (1)`, "users"},
+		{`insert into "billing"."invoices"(id) values (1)`, "billing.invoices"},
+		{`UPDATE public.orders SET total = 0`, "public.orders"},
+		{`DELETE FROM ONLY audit.events WHERE true`, "audit.events"},
+		{`TRUNCATE TABLE logs, events`, "logs"},
+		{`WITH x AS (SELECT 1) INSERT INTO t SELECT * FROM x`, ""},
+		{`SELECT * FROM users`, ""},
+	}
+	for _, tt := range tests {
+		if got := WriteTarget(tt.sql); got != tt.want {
+			t.Errorf("WriteTarget(%q) = %q, want %q", tt.sql, got, tt.want)
+		}
+	}
+}
+
+func TestReturningBody(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+		ok   bool
+	}{
+		{"INSERT INTO users (id) VALUES (1);\n", "INSERT INTO users (id) VALUES (1)", true},
+		{"-- seed users\nINSERT INTO users (id) VALUES (1)", "-- seed users\nINSERT INTO users (id) VALUES (1)", true},
+		{"INSERT INTO users (id) VALUES (1) -- first user", "", false},
+		{"INSERT INTO users (id) VALUES (1) RETURNING id", "", false},
+		{"INSERT INTO users (id) VALUES (1) ON CONFLICT (id) DO UPDATE SET id = 1", "", false},
+		{"INSERT INTO a VALUES (1); INSERT INTO b VALUES (1)", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := returningBody(tt.sql)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("returningBody(%q) = %q, %v, want %q, %v", tt.sql, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+// Package mysql executes seeding SQL tasks against MySQL databases.
+// Importing it registers the executor for dsn.DBTypeMySQL with sqlexec.
+// It mirrors the PostgreSQL executor in internal/sqlexec: reads return
+// {columns, rows}, writes run in a transaction and report rows_affected, and
+// table metadata is read from information_schema.
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"seedfast/cli/internal/dsn"
+	"seedfast/cli/internal/sqlexec"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// Executor executes SQL statements against a MySQL database.
+type Executor struct {
+	// DB is the MySQL connection pool
+	DB *sql.DB
+	// inspector provides schema metadata caching and introspection
+	inspector *SchemaInspector
+}
+
+var _ sqlexec.Executor = (*Executor)(nil)
+
+func init() {
+	sqlexec.Register(dsn.DBTypeMySQL, func(ctx context.Context, rawDSN string) (sqlexec.Executor, error) {
+		e, err := Open(ctx, rawDSN)
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	})
+}
+
+// Open connects to the database of a mysql:// DSN and verifies the connection.
+func Open(ctx context.Context, rawDSN string) (*Executor, error) {
+	driverDSN, err := dsn.MySQLDriverDSN(rawDSN)
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("mysql", driverDSN)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return New(db), nil
+}
+
+// New creates an Executor from an existing connection pool.
+func New(db *sql.DB) *Executor {
+	return &Executor{DB: db, inspector: NewSchemaInspector(db)}
+}
+
+// Close closes the connection pool.
+func (e *Executor) Close() error {
+	return e.DB.Close()
+}
+
+// GetSchemaInfo returns primary key, auto-increment and enum metadata for a table.
+func (e *Executor) GetSchemaInfo(ctx context.Context, tableName string) (*sqlexec.SchemaInfo, error) {
+	return e.inspector.GetSchemaInfo(ctx, tableName)
+}
+
+// ServerVersion returns the server version, e.g. "8.0.36".
+func (e *Executor) ServerVersion(ctx context.Context) (string, error) {
+	var v string
+	err := e.DB.QueryRowContext(ctx, "SELECT VERSION()").Scan(&v)
+	return v, err
+}
+
+// ExecuteSQLInSchema runs SQL and returns a JSON payload in the same format as
+// the PostgreSQL executor. A non-empty schema selects the database with USE;
+// otherwise statements rely on qualified names or the database of the DSN.
+func (e *Executor) ExecuteSQLInSchema(ctx context.Context, query string, isWrite bool, schema string) (string, error) {
+	res := sqlexec.Result{
+		Columns: []string{},
+		Rows:    [][]any{},
+	}
+	fail := func(err error) (string, error) {
+		res.Error = err.Error()
+		b, _ := res.MarshalJSON()
+		return string(b), nil
+	}
+
+	conn, err := e.DB.Conn(ctx)
+	if err != nil {
+		return fail(err)
+	}
+	defer conn.Close()
+
+	if schema != "" {
+		if _, err := conn.ExecContext(ctx, "USE "+quoteIdent(schema)); err != nil {
+			return fail(fmt.Errorf("select database %s: %w", schema, err))
+		}
+	}
+
+	if isWrite {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return fail(err)
+		}
+		defer tx.Rollback()
+
+		r, err := tx.ExecContext(ctx, query)
+		if err != nil {
+			return fail(err)
+		}
+		res.RowsAffected, _ = r.RowsAffected()
+		if err := tx.Commit(); err != nil {
+			return fail(fmt.Errorf("commit failed: %w", err))
+		}
+		b, _ := res.MarshalJSON()
+		return string(b), nil
+	}
+
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return fail(err)
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return fail(err)
+	}
+	binary := make([]bool, len(types))
+	for i, ct := range types {
+		res.Columns = append(res.Columns, ct.Name())
+		binary[i] = isBinaryType(ct.DatabaseTypeName())
+	}
+
+	for rows.Next() {
+		vals := make([]any, len(types))
+		ptrs := make([]any, len(types))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			res.Error = err.Error()
+			break
+		}
+		res.Rows = append(res.Rows, convertRow(vals, binary))
+	}
+	if err := rows.Err(); err != nil {
+		res.Error = err.Error()
+	}
+
+	b, err := res.MarshalJSON()
+	if err != nil {
+		return fail(fmt.Errorf("JSON marshal error: %w", err))
+	}
+	return string(b), nil
+}
+
+// isBinaryType reports whether values of a MySQL column type are raw bytes
+// rather than text.
+func isBinaryType(name string) bool {
+	switch strings.ToUpper(name) {
+	case "BINARY", "VARBINARY", "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "BIT", "GEOMETRY":
+		return true
+	}
+	return false
+}
+
+// convertRow turns the driver's text-protocol []byte values into strings,
+// keeping binary columns as bytes so they are hex- or UUID-encoded in JSON.
+func convertRow(vals []any, binary []bool) []any {
+	for i, v := range vals {
+		if b, ok := v.([]byte); ok && !binary[i] {
+			vals[i] = string(b)
+		}
+	}
+	return vals
+}
+
+// quoteIdent quotes a MySQL identifier with backticks.
+func quoteIdent(name string) string {
+	return "`" + strings.ReplaceAll(strings.Trim(name, "`"), "`", "``") + "`"
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+
+	"seedfast/cli/internal/sqlexec"
+)
+
+// SchemaInspector reads table metadata from information_schema and caches it.
+// It is the MySQL counterpart of sqlexec.SchemaInspector.
+type SchemaInspector struct {
+	db    *sql.DB
+	cache map[string]*sqlexec.SchemaInfo
+	mu    sync.RWMutex
+}
+
+// NewSchemaInspector creates a new SchemaInspector with the given connection pool.
+func NewSchemaInspector(db *sql.DB) *SchemaInspector {
+	return &SchemaInspector{db: db, cache: make(map[string]*sqlexec.SchemaInfo)}
+}
+
+// GetSchemaInfo retrieves or caches schema information for a table.
+// The tableName can be either "table" (in the current database) or "database.table".
+func (si *SchemaInspector) GetSchemaInfo(ctx context.Context, tableName string) (*sqlexec.SchemaInfo, error) {
+	si.mu.RLock()
+	if info, ok := si.cache[tableName]; ok {
+		si.mu.RUnlock()
+		return info, nil
+	}
+	si.mu.RUnlock()
+
+	schema, table := splitTableName(tableName)
+	info := &sqlexec.SchemaInfo{
+		TableName:        tableName,
+		AutoIncrement:    make(map[string]bool),
+		CheckConstraints: make(map[string]string),
+		EnumValues:       make(map[string][]string),
+	}
+
+	// An empty schema means the database selected by the connection
+	const schemaCond = `TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ?`
+
+	rows, err := si.db.QueryContext(ctx, `
+		SELECT COLUMN_NAME
+		FROM information_schema.KEY_COLUMN_USAGE
+		WHERE `+schemaCond+` AND CONSTRAINT_NAME = 'PRIMARY'
+		ORDER BY ORDINAL_POSITION`, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var col string
+		if err := rows.Scan(&col); err == nil {
+			info.PrimaryKeyCols = append(info.PrimaryKeyCols, col)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Auto-increment columns and ENUM types come from the column definitions
+	rows, err = si.db.QueryContext(ctx, `
+		SELECT COLUMN_NAME, EXTRA, COLUMN_TYPE
+		FROM information_schema.COLUMNS
+		WHERE `+schemaCond, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var col, extra, colType string
+		if err := rows.Scan(&col, &extra, &colType); err != nil {
+			continue
+		}
+		if strings.Contains(strings.ToLower(extra), "auto_increment") {
+			info.AutoIncrement[col] = true
+		}
+		if values := enumValues(colType); len(values) > 0 {
+			info.EnumValues[col] = values
+			info.CheckConstraints[col] = colType
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	si.mu.Lock()
+	si.cache[tableName] = info
+	si.mu.Unlock()
+	return info, nil
+}
+
+// ClearCache clears all cached schema information.
+func (si *SchemaInspector) ClearCache() {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	si.cache = make(map[string]*sqlexec.SchemaInfo)
+}
+
+// splitTableName splits "database.table" into its parts, stripping backticks.
+// An unqualified name returns an empty database.
+func splitTableName(name string) (schema, table string) {
+	name = strings.ReplaceAll(name, "`", "")
+	if s, t, ok := strings.Cut(name, "."); ok {
+		return s, t
+	}
+	return "", name
+}
+
+// enumValues extracts the allowed values of an ENUM column type such as
+// enum('queued','running'). Quotes inside values are doubled, as in SQL.
+func enumValues(columnType string) []string {
+	lower := strings.ToLower(columnType)
+	if !strings.HasPrefix(lower, "enum(") || !strings.HasSuffix(columnType, ")") {
+		return nil
+	}
+	body := columnType[len("enum(") : len(columnType)-1]
+	var values []string
+	var cur strings.Builder
+	inQuote := false
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '\'' && inQuote && i+1 < len(body) && body[i+1] == '\'':
+			cur.WriteByte('\'')
+			i++
+		case c == '\'':
+			inQuote = !inQuote
+			if !inQuote {
+				values = append(values, cur.String())
+				cur.Reset()
+			}
+		case inQuote:
+			cur.WriteByte(c)
+		}
+	}
+	return values
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package mysql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEnumValues(t *testing.T) {
+	got := enumValues("enum('queued','running','it''s done')")
+	want := []string{"queued", "running", "it's done"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("enumValues() = %q, want %q", got, want)
+	}
+	if v := enumValues("varchar(20)"); v != nil {
+		t.Fatalf("enumValues(varchar) = %q", v)
+	}
+}
+
+func TestSplitTableName(t *testing.T) {
+	if s, tb := splitTableName("`shop`.`orders`"); s != "shop" || tb != "orders" {
+		t.Fatalf("qualified: %q, %q", s, tb)
+	}
+	if s, tb := splitTableName("orders"); s != "" || tb != "orders" {
+		t.Fatalf("unqualified: %q, %q", s, tb)
+	}
+}
+
+func TestConvertRow(t *testing.T) {
+	row := convertRow([]any{[]byte("text"), []byte{0xde, 0xad}, int64(7), nil}, []bool{false, true, false, false})
+	if row[0] != "text" || !reflect.DeepEqual(row[1], []byte{0xde, 0xad}) || row[2] != int64(7) || row[3] != nil {
+		t.Fatalf("convertRow() = %#v", row)
+	}
+	if quoteIdent("we`ird") != "`we``ird`" {
+		t.Fatalf("quoteIdent() = %s", quoteIdent("we`ird"))
+	}
+}
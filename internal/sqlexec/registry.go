@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package sqlexec
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"seedfast/cli/internal/dsn"
+)
+
+// Opener connects an Executor to the database of a normalized DSN.
+type Opener func(ctx context.Context, dsn string) (Executor, error)
+
+var (
+	openersMu sync.RWMutex
+	openers   = map[dsn.DBType]Opener{
+		dsn.DBTypePostgreSQL: openPostgres,
+	}
+)
+
+// Register makes an executor available for a database type. Dialect packages
+// call it from init, so importing them (usually with a blank import) is enough
+// to enable the dialect. A later registration for the same type replaces the
+// earlier one, which lets tests plug in a double.
+func Register(dbType dsn.DBType, open Opener) {
+	if open == nil {
+		panic("sqlexec: Register opener is nil for " + string(dbType))
+	}
+	openersMu.Lock()
+	defer openersMu.Unlock()
+	openers[dbType] = open
+}
+
+// Dialects returns the registered database types in sorted order.
+func Dialects() []dsn.DBType {
+	openersMu.RLock()
+	defer openersMu.RUnlock()
+	types := make([]dsn.DBType, 0, len(openers))
+	for t := range openers {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// Open detects the database type of a normalized DSN and opens an Executor
+// with the opener registered for it.
+func Open(ctx context.Context, normalizedDSN string) (Executor, error) {
+	dbType := dsn.DetectDBType(normalizedDSN)
+	openersMu.RLock()
+	open, ok := openers[dbType]
+	openersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no executor registered for database type %q", dbType)
+	}
+	return open(ctx, normalizedDSN)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package sqlexec
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"seedfast/cli/internal/dsn"
+)
+
+type fakeExecutor struct {
+	dsn    string
+	closed bool
+}
+
+func (f *fakeExecutor) ExecuteSQLInSchema(ctx context.Context, sql string, isWrite bool, schema string) (string, error) {
+	return `{"columns":[],"rows":[],"rows_affected":1}`, nil
+}
+
+func (f *fakeExecutor) GetSchemaInfo(ctx context.Context, tableName string) (*SchemaInfo, error) {
+	return &SchemaInfo{TableName: tableName}, nil
+}
+
+func (f *fakeExecutor) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestRegisterAndOpen(t *testing.T) {
+	openersMu.RLock()
+	saved := openers[dsn.DBTypeMySQL]
+	openersMu.RUnlock()
+	t.Cleanup(func() {
+		openersMu.Lock()
+		defer openersMu.Unlock()
+		if saved == nil {
+			delete(openers, dsn.DBTypeMySQL)
+		} else {
+			openers[dsn.DBTypeMySQL] = saved
+		}
+	})
+
+	Register(dsn.DBTypeMySQL, func(ctx context.Context, d string) (Executor, error) {
+		return &fakeExecutor{dsn: d}, nil
+	})
+	if !slices.Contains(Dialects(), dsn.DBTypeMySQL) || !slices.Contains(Dialects(), dsn.DBTypePostgreSQL) {
+		t.Fatalf("Dialects() = %v", Dialects())
+	}
+
+	exec, err := Open(context.Background(), "mysql://app@db/shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake, ok := exec.(*fakeExecutor)
+	if !ok || fake.dsn != "mysql://app@db/shop" {
+		t.Fatalf("Open() = %#v", exec)
+	}
+	exec.Close()
+	if !fake.closed {
+		t.Fatal("Close() not forwarded")
+	}
+
+	if _, err := Open(context.Background(), "oracle://app@db/shop"); err == nil {
+		t.Fatal("expected error for unregistered dialect")
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+// Package sqlite executes seeding SQL tasks against SQLite database files using
+// a pure-Go driver, so no C toolchain is needed. Reads return {columns, rows},
+// writes run in a transaction and report rows_affected, and table metadata is
+// read with pragma_table_info and pragma_foreign_key_list.
+// Importing it registers the executor for dsn.DBTypeSQLite with sqlexec.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"seedfast/cli/internal/dsn"
+	"seedfast/cli/internal/sqlexec"
+
+	_ "modernc.org/sqlite"
+)
+
+// Executor executes SQL statements against a SQLite database file.
+type Executor struct {
+	// DB is the connection pool; it holds a single connection because SQLite
+	// allows one writer at a time
+	DB *sql.DB
+	// inspector provides schema metadata caching and introspection
+	inspector *SchemaInspector
+}
+
+var _ sqlexec.Executor = (*Executor)(nil)
+
+func init() {
+	sqlexec.Register(dsn.DBTypeSQLite, func(ctx context.Context, rawDSN string) (sqlexec.Executor, error) {
+		e, err := Open(ctx, rawDSN)
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	})
+}
+
+// Open opens the database file of a sqlite:// DSN. The file must exist; seeding
+// an empty, newly created database is almost always a mistyped path.
+func Open(ctx context.Context, rawDSN string) (*Executor, error) {
+	path, err := dsn.SQLiteFile(rawDSN)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("database file %s does not exist", path)
+		}
+		return nil, err
+	}
+	driverDSN, err := dsn.SQLiteDriverDSN(rawDSN)
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", driverDSN)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return New(db), nil
+}
+
+// New creates an Executor from an existing connection pool.
+func New(db *sql.DB) *Executor {
+	return &Executor{DB: db, inspector: NewSchemaInspector(db)}
+}
+
+// Close closes the connection pool.
+func (e *Executor) Close() error {
+	return e.DB.Close()
+}
+
+// GetSchemaInfo returns primary key and auto-increment metadata for a table.
+func (e *Executor) GetSchemaInfo(ctx context.Context, tableName string) (*sqlexec.SchemaInfo, error) {
+	return e.inspector.GetSchemaInfo(ctx, tableName)
+}
+
+// GetForeignKeys returns the foreign key dependencies between tables of the main database.
+func (e *Executor) GetForeignKeys(ctx context.Context) ([]sqlexec.ForeignKey, error) {
+	return e.inspector.GetForeignKeys(ctx)
+}
+
+// ServerVersion returns the version of the SQLite library, e.g. "3.46.0".
+func (e *Executor) ServerVersion(ctx context.Context) (string, error) {
+	var v string
+	err := e.DB.QueryRowContext(ctx, "SELECT sqlite_version()").Scan(&v)
+	return v, err
+}
+
+// ExecuteSQLInSchema runs SQL and returns a JSON payload in the same format as
+// the PostgreSQL executor. SQLite has no schemas beyond attached databases, so
+// the schema argument is ignored; statements use plain or main-qualified names.
+func (e *Executor) ExecuteSQLInSchema(ctx context.Context, query string, isWrite bool, schema string) (string, error) {
+	res := sqlexec.Result{
+		Columns: []string{},
+		Rows:    [][]any{},
+	}
+	fail := func(err error) (string, error) {
+		res.Error = err.Error()
+		b, _ := res.MarshalJSON()
+		return string(b), nil
+	}
+
+	if isWrite {
+		tx, err := e.DB.BeginTx(ctx, nil)
+		if err != nil {
+			return fail(err)
+		}
+		defer tx.Rollback()
+
+		r, err := tx.ExecContext(ctx, query)
+		if err != nil {
+			return fail(err)
+		}
+		res.RowsAffected, _ = r.RowsAffected()
+		if err := tx.Commit(); err != nil {
+			return fail(fmt.Errorf("commit failed: %w", err))
+		}
+		b, _ := res.MarshalJSON()
+		return string(b), nil
+	}
+
+	rows, err := e.DB.QueryContext(ctx, query)
+	if err != nil {
+		return fail(err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return fail(err)
+	}
+	res.Columns = cols
+
+	for rows.Next() {
+		vals := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			res.Error = err.Error()
+			break
+		}
+		res.Rows = append(res.Rows, vals)
+	}
+	if err := rows.Err(); err != nil {
+		res.Error = err.Error()
+	}
+
+	b, err := res.MarshalJSON()
+	if err != nil {
+		return fail(fmt.Errorf("JSON marshal error: %w", err))
+	}
+	return string(b), nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"seedfast/cli/internal/sqlexec"
+)
+
+func newTestDB(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "dev.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	_, err = db.Exec(`
+		CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT NOT NULL);
+		CREATE TABLE orders (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL REFERENCES users(id), total REAL);
+		CREATE TABLE order_tags (order_id INTEGER REFERENCES orders(id), tag TEXT, PRIMARY KEY (order_id, tag));`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestExecutor(t *testing.T) {
+	ctx := context.Background()
+	path := newTestDB(t)
+
+	exec, err := sqlexec.Open(ctx, "sqlite://"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer exec.Close()
+
+	if v := sqlexec.ServerVersion(ctx, exec); !strings.HasPrefix(v, "3.") {
+		t.Errorf("ServerVersion = %q, want 3.x", v)
+	}
+
+	var res sqlexec.Result
+	out, _ := exec.ExecuteSQLInSchema(ctx, `INSERT INTO users (email) VALUES ('a@example.com'), ('b@example.com')`, true, "")
+	if err := json.Unmarshal([]byte(out), &res); err != nil || res.Error != "" || res.RowsAffected != 2 {
+		t.Fatalf("insert: %s", out)
+	}
+
+	// Foreign keys are enforced by default
+	out, _ = exec.ExecuteSQLInSchema(ctx, `INSERT INTO orders (user_id, total) VALUES (42, 9.5)`, true, "")
+	res = sqlexec.Result{}
+	if json.Unmarshal([]byte(out), &res); res.Error == "" {
+		t.Fatalf("expected foreign key violation: %s", out)
+	}
+
+	out, _ = exec.ExecuteSQLInSchema(ctx, `SELECT id, email FROM users ORDER BY id`, false, "")
+	res = sqlexec.Result{}
+	if err := json.Unmarshal([]byte(out), &res); err != nil || res.Error != "" {
+		t.Fatalf("select: %s", out)
+	}
+	if !reflect.DeepEqual(res.Columns, []string{"id", "email"}) || len(res.Rows) != 2 || res.Rows[1][1] != "b@example.com" {
+		t.Fatalf("select: %s", out)
+	}
+
+	info, err := exec.GetSchemaInfo(ctx, "main.orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(info.PrimaryKeyCols, []string{"id"}) || !info.AutoIncrement["id"] {
+		t.Fatalf("orders: %+v", info)
+	}
+	info, err = exec.GetSchemaInfo(ctx, "order_tags")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(info.PrimaryKeyCols, []string{"order_id", "tag"}) || len(info.AutoIncrement) != 0 {
+		t.Fatalf("order_tags: %+v", info)
+	}
+
+	fks, err := exec.(*Executor).GetForeignKeys(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []sqlexec.ForeignKey{{Table: "order_tags", RefTable: "orders"}, {Table: "orders", RefTable: "users"}}
+	if !reflect.DeepEqual(fks, want) {
+		t.Fatalf("GetForeignKeys() = %v, want %v", fks, want)
+	}
+}
+
+func TestOpenMissingFile(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.db")
+	if _, err := Open(context.Background(), "sqlite://"+missing); err == nil {
+		t.Fatal("expected error for missing database file")
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+
+	"seedfast/cli/internal/sqlexec"
+)
+
+// SchemaInspector reads table metadata with SQLite's table-valued pragma
+// functions and caches it. It is the SQLite counterpart of sqlexec.SchemaInspector.
+type SchemaInspector struct {
+	db    *sql.DB
+	cache map[string]*sqlexec.SchemaInfo
+	mu    sync.RWMutex
+}
+
+// NewSchemaInspector creates a new SchemaInspector with the given connection pool.
+func NewSchemaInspector(db *sql.DB) *SchemaInspector {
+	return &SchemaInspector{db: db, cache: make(map[string]*sqlexec.SchemaInfo)}
+}
+
+// GetSchemaInfo retrieves or caches schema information for a table.
+// The tableName can be either "table" or "database.table" for attached databases.
+func (si *SchemaInspector) GetSchemaInfo(ctx context.Context, tableName string) (*sqlexec.SchemaInfo, error) {
+	si.mu.RLock()
+	if info, ok := si.cache[tableName]; ok {
+		si.mu.RUnlock()
+		return info, nil
+	}
+	si.mu.RUnlock()
+
+	schema, table := splitTableName(tableName)
+	info := &sqlexec.SchemaInfo{
+		TableName:        tableName,
+		AutoIncrement:    make(map[string]bool),
+		CheckConstraints: make(map[string]string),
+		EnumValues:       make(map[string][]string),
+	}
+
+	// pk is the 1-based position of the column in the primary key, 0 otherwise
+	rows, err := si.db.QueryContext(ctx, `
+		SELECT name, type, pk
+		FROM pragma_table_info(?, ?)
+		WHERE pk > 0
+		ORDER BY pk`, table, schema)
+	if err != nil {
+		return nil, err
+	}
+	var pkTypes []string
+	for rows.Next() {
+		var col, colType string
+		var pk int
+		if err := rows.Scan(&col, &colType, &pk); err == nil {
+			info.PrimaryKeyCols = append(info.PrimaryKeyCols, col)
+			pkTypes = append(pkTypes, colType)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// A single INTEGER PRIMARY KEY column aliases the rowid and is assigned automatically
+	if len(pkTypes) == 1 && strings.EqualFold(strings.TrimSpace(pkTypes[0]), "INTEGER") {
+		withoutRowID, err := si.isWithoutRowID(ctx, schema, table)
+		if err != nil {
+			return nil, err
+		}
+		if !withoutRowID {
+			info.AutoIncrement[info.PrimaryKeyCols[0]] = true
+		}
+	}
+
+	si.mu.Lock()
+	si.cache[tableName] = info
+	si.mu.Unlock()
+	return info, nil
+}
+
+// isWithoutRowID reports whether a table was created WITHOUT ROWID.
+func (si *SchemaInspector) isWithoutRowID(ctx context.Context, schema, table string) (bool, error) {
+	var wr int
+	err := si.db.QueryRowContext(ctx, `
+		SELECT wr FROM pragma_table_list(?) WHERE schema = ?`, table, schema).Scan(&wr)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return wr != 0, err
+}
+
+// GetForeignKeys returns all foreign key dependencies between tables of the
+// main database. Internal sqlite_ tables are excluded.
+func (si *SchemaInspector) GetForeignKeys(ctx context.Context) ([]sqlexec.ForeignKey, error) {
+	rows, err := si.db.QueryContext(ctx, `
+		SELECT DISTINCT m.name, fk."table"
+		FROM sqlite_schema AS m, pragma_foreign_key_list(m.name) AS fk
+		WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite\_%' ESCAPE '\'
+		ORDER BY m.name, fk."table"`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fks []sqlexec.ForeignKey
+	for rows.Next() {
+		var fk sqlexec.ForeignKey
+		if err := rows.Scan(&fk.Table, &fk.RefTable); err != nil {
+			return nil, err
+		}
+		fks = append(fks, fk)
+	}
+	return fks, rows.Err()
+}
+
+// ClearCache clears all cached schema information.
+func (si *SchemaInspector) ClearCache() {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	si.cache = make(map[string]*sqlexec.SchemaInfo)
+}
+
+// splitTableName splits "database.table" into its parts, stripping quotes.
+// An unqualified name returns the main database.
+func splitTableName(name string) (schema, table string) {
+	name = strings.NewReplacer(`"`, "", "`", "", "[", "", "]", "").Replace(name)
+	if s, t, ok := strings.Cut(name, "."); ok {
+		return s, t
+	}
+	return "main", name
+}
@@ -0,0 +1,296 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package sqlexec
+
+import (
+	"regexp"
+	"strings"
+
+	"seedfast/cli/internal/dsn"
+)
+
+var (
+	// writeKeywordRegex finds data-modifying clauses anywhere in a statement,
+	// including inside CTEs and after INSERT ... SELECT.
+	writeKeywordRegex = regexp.MustCompile(`(?i)\b(INSERT(?:\s+IGNORE)?(?:\s+INTO)?|REPLACE(?:\s+INTO)?|UPDATE(?:\s+IGNORE)?|DELETE(?:\s+IGNORE)?\s+FROM|TRUNCATE(?:\s+TABLE)?|COPY|MERGE\s+INTO)\b`)
+	// tableNameRegex matches a possibly qualified and quoted table name.
+	tableNameRegex = regexp.MustCompile("^\\s*(?i:ONLY\\s+)?((?:\"(?:[^\"]|\"\")+\"|`[^`]+`|[A-Za-z_][A-Za-z0-9_$]*)(?:\\s*\\.\\s*(?:\"(?:[^\"]|\"\")+\"|`[^`]+`|[A-Za-z_][A-Za-z0-9_$]*))?)")
+	// copyFromRegex tells COPY ... FROM (a write) from COPY ... TO (a read).
+	copyFromRegex = regexp.MustCompile(`(?i)^\s*(?:\([^)]*\))?\s*FROM\b`)
+	// listContinueRegex matches the separator between TRUNCATE targets.
+	listContinueRegex = regexp.MustCompile(`^\s*\*?\s*,`)
+	firstWordRegex    = regexp.MustCompile(`^\s*([A-Za-z]+)`)
+)
+
+// readOnlyStarts are statements that write no tables unless they contain a
+// data-modifying clause, which WriteTargets finds separately.
+var readOnlyStarts = map[string]bool{
+	"SELECT": true, "VALUES": true, "WITH": true, "SHOW": true, "SET": true, "RESET": true,
+	"BEGIN": true, "START": true, "COMMIT": true, "ROLLBACK": true, "EXPLAIN": true, "ANALYZE": true,
+	"INSERT": true, "UPDATE": true, "DELETE": true, "TRUNCATE": true, "COPY": true, "MERGE": true, "REPLACE": true,
+}
+
+// WriteOp is one data-modifying clause found by WriteOps.
+type WriteOp struct {
+	// Verb is the upper-case statement keyword: INSERT, REPLACE, UPDATE,
+	// DELETE, TRUNCATE, COPY or MERGE
+	Verb string
+	// Table is the written table, without quotes and as written
+	Table string
+}
+
+// WriteTargets returns every table written by sql, which may hold several
+// statements, without quotes and as written (possibly unqualified). Writes in
+// CTEs and multi-table TRUNCATE statements are included. ok is false when a
+// statement may write tables that cannot be named, e.g. DDL or DO blocks.
+// dbType selects how string literals, identifiers and comments are lexed.
+func WriteTargets(sql string, dbType dsn.DBType) (targets []string, ok bool) {
+	ops, ok := WriteOps(sql, dbType)
+	if !ok {
+		return nil, false
+	}
+	for _, op := range ops {
+		targets = append(targets, op.Table)
+	}
+	return targets, true
+}
+
+// WriteOps is WriteTargets with the verb of every write, in statement order.
+// In a single statement, data-modifying CTEs come before the main clause.
+func WriteOps(sql string, dbType dsn.DBType) (ops []WriteOp, ok bool) {
+	stripped, ok := stripLiterals(sql, syntaxFor(dbType))
+	if !ok {
+		return nil, false
+	}
+	for _, stmt := range strings.Split(stripped, ";") {
+		if strings.TrimSpace(stmt) == "" {
+			continue
+		}
+		first := firstWordRegex.FindStringSubmatch(stmt)
+		if first == nil || !readOnlyStarts[strings.ToUpper(first[1])] {
+			return nil, false
+		}
+		for _, loc := range writeKeywordRegex.FindAllStringSubmatchIndex(stmt, -1) {
+			keyword := strings.ToUpper(strings.Fields(stmt[loc[2]:loc[3]])[0])
+			if loc[0] > 0 && strings.ContainsRune("\".`", rune(stmt[loc[0]-1])) {
+				// Part of a quoted or qualified identifier
+				continue
+			}
+			rest := stmt[loc[1]:]
+			switch keyword {
+			case "UPDATE":
+				// ON CONFLICT DO UPDATE, SELECT ... FOR [NO KEY] UPDATE and ON UPDATE actions
+				if prev := previousWord(stmt[:loc[0]]); prev == "DO" || prev == "FOR" || prev == "KEY" || prev == "ON" {
+					continue
+				}
+			case "REPLACE":
+				if strings.HasPrefix(strings.TrimSpace(rest), "(") {
+					// The replace() string function
+					continue
+				}
+			}
+			name := tableNameRegex.FindStringSubmatch(rest)
+			if name == nil {
+				return nil, false
+			}
+			if keyword == "COPY" && !copyFromRegex.MatchString(rest[len(name[0]):]) {
+				continue
+			}
+			ops = append(ops, WriteOp{Verb: keyword, Table: unquoteName(name[1])})
+			for keyword == "TRUNCATE" {
+				rest = rest[len(name[0]):]
+				sep := listContinueRegex.FindString(rest)
+				if sep == "" {
+					break
+				}
+				rest = rest[len(sep):]
+				if name = tableNameRegex.FindStringSubmatch(rest); name == nil {
+					return nil, false
+				}
+				ops = append(ops, WriteOp{Verb: keyword, Table: unquoteName(name[1])})
+			}
+		}
+	}
+	return ops, true
+}
+
+// lexSyntax describes how a dialect writes string literals, quoted
+// identifiers and comments.
+type lexSyntax struct {
+	// backslashEscapes makes a backslash escape the next character in every
+	// string literal; otherwise only in PostgreSQL E'...' literals
+	backslashEscapes bool
+	// doubleQuotedStrings treats "..." as a string literal rather than an identifier
+	doubleQuotedStrings bool
+	// dollarQuotes enables PostgreSQL $tag$...$tag$ strings
+	dollarQuotes bool
+	// hashComments starts a comment at # (MySQL)
+	hashComments bool
+	// spacedDashComments requires whitespace after -- (MySQL)
+	spacedDashComments bool
+	// bracketIdentifiers quotes identifiers with [...] (SQLite)
+	bracketIdentifiers bool
+}
+
+// syntaxFor returns the lexical rules of a database type; PostgreSQL rules
+// apply to unknown types.
+func syntaxFor(dbType dsn.DBType) lexSyntax {
+	switch dbType {
+	case dsn.DBTypeMySQL:
+		return lexSyntax{backslashEscapes: true, doubleQuotedStrings: true, hashComments: true, spacedDashComments: true}
+	case dsn.DBTypeSQLite:
+		return lexSyntax{bracketIdentifiers: true}
+	default:
+		return lexSyntax{dollarQuotes: true}
+	}
+}
+
+// stripLiterals blanks out string literals and comments so that keywords and
+// semicolons inside them are ignored. Quoted identifiers are kept. ok is false
+// when sql contains MySQL executable comments, whose content runs as SQL.
+func stripLiterals(sql string, syn lexSyntax) (string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '\'' || c == '"' && syn.doubleQuotedStrings:
+			escapes := syn.backslashEscapes || c == '\'' && isEscapePrefix(sql[:i])
+			i = quotedEnd(sql, i, c, escapes)
+			b.WriteString("''")
+		case c == '"' || c == '`' || c == '[' && syn.bracketIdentifiers:
+			end := c
+			if c == '[' {
+				end = ']'
+			}
+			start := i
+			i = quotedEnd(sql, i, end, false)
+			b.WriteString(sql[start:min(i+1, len(sql))])
+		case c == '-' && strings.HasPrefix(sql[i:], "--") && (!syn.spacedDashComments || i+2 == len(sql) || sql[i+2] <= ' '),
+			c == '#' && syn.hashComments:
+			for i < len(sql) && sql[i] != '\n' {
+				i++
+			}
+			b.WriteByte('\n')
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			if syn.hashComments && strings.HasPrefix(sql[i:], "/*!") {
+				return "", false
+			}
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end + 3
+			}
+			b.WriteByte(' ')
+		case c == '$' && syn.dollarQuotes && (i == 0 || !isIdentByte(sql[i-1])):
+			tag := dollarTag(sql[i:])
+			if tag == "" {
+				b.WriteByte(c)
+				continue
+			}
+			end := strings.Index(sql[i+len(tag):], tag)
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += len(tag) + end + len(tag) - 1
+			}
+			b.WriteString("''")
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), true
+}
+
+// quotedEnd returns the index of the quote closing the literal or identifier
+// opened at sql[start], or len(sql) when it is unterminated. A doubled quote
+// stands for itself; escapes makes a backslash skip the next character.
+func quotedEnd(sql string, start int, quote byte, escapes bool) int {
+	for i := start + 1; i < len(sql); i++ {
+		switch sql[i] {
+		case '\\':
+			if escapes {
+				i++
+			}
+		case quote:
+			if i+1 < len(sql) && sql[i+1] == quote {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return len(sql)
+}
+
+// isEscapePrefix reports whether before ends with the E prefix of a
+// PostgreSQL escape string constant (E'...'), not with an identifier.
+func isEscapePrefix(before string) bool {
+	n := len(before)
+	if n == 0 || before[n-1] != 'E' && before[n-1] != 'e' {
+		return false
+	}
+	return n == 1 || !isIdentByte(before[n-2])
+}
+
+// isIdentByte reports whether c can be part of an unquoted identifier.
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+// dollarTag returns the opening tag of a dollar-quoted string ($$ or $tag$)
+// at the start of s, or "" when s does not start one.
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		if c == '$' {
+			return s[:i+1]
+		}
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 1 && c >= '0' && c <= '9') {
+			return ""
+		}
+	}
+	return ""
+}
+
+// previousWord returns the last word of s in upper case.
+func previousWord(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[len(fields)-1])
+}
+
+// unquoteName removes identifier quotes and whitespace around the dot.
+func unquoteName(name string) string {
+	parts := splitQualified(name)
+	for i := range parts {
+		p := strings.TrimSpace(parts[i])
+		if len(p) >= 2 && (p[0] == '"' || p[0] == '`') {
+			p = strings.ReplaceAll(p[1:len(p)-1], `""`, `"`)
+		}
+		parts[i] = p
+	}
+	return strings.Join(parts, ".")
+}
+
+// splitQualified splits a name at dots outside identifier quotes.
+func splitQualified(name string) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i := 0; i < len(name); i++ {
+		switch name[i] {
+		case '"', '`':
+			quoted = !quoted
+		case '.':
+			if !quoted {
+				parts = append(parts, name[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, name[start:])
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package sqlexec
+
+import (
+	"reflect"
+	"testing"
+
+	"seedfast/cli/internal/dsn"
+)
+
+func TestWriteTargets(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want []string
+		ok   bool
+	}{
+		{`INSERT INTO users (id) VALUES (1)`, []string{"users"}, true},
+		{`insert into "billing"."invoices"(id) values (1)`, []string{"billing.invoices"}, true},
+		{`UPDATE public.orders SET total = 0`, []string{"public.orders"}, true},
+		{`DELETE FROM ONLY audit.events WHERE true`, []string{"audit.events"}, true},
+		{`TRUNCATE TABLE logs, ONLY audit.events * RESTART IDENTITY`, []string{"logs", "audit.events"}, true},
+		{`WITH x AS (SELECT 1) INSERT INTO t SELECT * FROM x`, []string{"t"}, true},
+		{`WITH d AS (DELETE FROM a RETURNING *) INSERT INTO b SELECT * FROM d`, []string{"a", "b"}, true},
+		{`INSERT INTO a VALUES (1); INSERT INTO audit.b VALUES (2);`, []string{"a", "audit.b"}, true},
+		{`INSERT INTO t (id) VALUES (1) ON CONFLICT (id) DO UPDATE SET id = 2`, []string{"t"}, true},
+		{`INSERT INTO t (note) VALUES ('UPDATE x; DELETE FROM y') -- TRUNCATE z`, []string{"t"}, true},
+		{`INSERT INTO t (body) VALUES ($$DROP TABLE x$$)`, []string{"t"}, true},
+		{"INSERT INTO `shop`.`orders` (id) VALUES (1)", []string{"shop.orders"}, true},
+		{`SELECT * FROM users FOR UPDATE`, nil, true},
+		{`SELECT replace(name, 'a', 'b') FROM users`, nil, true},
+		{`COPY users FROM STDIN`, []string{"users"}, true},
+		{`COPY users (id, name) FROM STDIN`, []string{"users"}, true},
+		{`COPY users TO STDOUT`, nil, true},
+		{`SELECT setval('users_id_seq', 10)`, nil, true},
+		{`ALTER TABLE users DISABLE TRIGGER ALL`, nil, false},
+		{`INSERT INTO a VALUES (1); DROP TABLE b`, nil, false},
+		{`DO $$ BEGIN DELETE FROM b; END $$`, nil, false},
+	}
+	for _, tt := range tests {
+		got, ok := WriteTargets(tt.sql, dsn.DBTypePostgreSQL)
+		if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("WriteTargets(%q) = %q, %v, want %q, %v", tt.sql, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestWriteTargetsLexing(t *testing.T) {
+	tests := []struct {
+		dbType dsn.DBType
+		sql    string
+		want   []string
+		ok     bool
+	}{
+		// Backslashes only escape in E'...' literals
+		{dsn.DBTypePostgreSQL, `INSERT INTO public.allowed VALUES ('x\'); DELETE FROM public.users; --')`, []string{"public.allowed", "public.users"}, true},
+		{dsn.DBTypePostgreSQL, `INSERT INTO t VALUES (E'x\'); DELETE FROM u; --')`, []string{"t"}, true},
+		{dsn.DBTypePostgreSQL, `INSERT INTO t VALUES (e'x\\'); DELETE FROM u`, []string{"t", "u"}, true},
+		{dsn.DBTypePostgreSQL, `INSERT INTO t ("a'b") VALUES (1); DELETE FROM u; -- '`, []string{"t", "u"}, true},
+		{dsn.DBTypePostgreSQL, `INSERT INTO t VALUES (1); SELECT 1 AS a$b$; DELETE FROM u; SELECT 2 AS c$b$`, []string{"t", "u"}, true},
+		{dsn.DBTypeMySQL, `INSERT INTO t VALUES ('x\'); DELETE FROM u; --')`, []string{"t"}, true},
+		{dsn.DBTypeMySQL, `INSERT INTO t VALUES ("x'"); DELETE FROM u; -- '`, []string{"t", "u"}, true},
+		{dsn.DBTypeMySQL, "INSERT INTO t VALUES (1); # '\nDELETE FROM u; -- '", []string{"t", "u"}, true},
+		{dsn.DBTypeMySQL, `INSERT INTO t VALUES (1--1); DELETE FROM u`, []string{"t", "u"}, true},
+		{dsn.DBTypeMySQL, `INSERT INTO t VALUES (1); /*!50000 DELETE FROM u */`, nil, false},
+		{dsn.DBTypeSQLite, `INSERT INTO t ([a'b]) VALUES (1); DELETE FROM u; -- '`, []string{"t", "u"}, true},
+		{dsn.DBTypeSQLite, `INSERT INTO t VALUES ('x\'); DELETE FROM u; --')`, []string{"t", "u"}, true},
+	}
+	for _, tt := range tests {
+		got, ok := WriteTargets(tt.sql, tt.dbType)
+		if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("WriteTargets(%q, %s) = %q, %v, want %q, %v", tt.sql, tt.dbType, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+// Package tracking records the primary keys of rows inserted during a seeding
+// session so that exactly those rows can be removed later, leaving data that was
+// not created by Seedfast untouched.
+//
+// Keys are appended as JSON lines to <user dir>/tracking/<session id>.jsonl while
+// the session runs. Key values are stored in their text representation and cast
+// back to the column types when rows are deleted. Undo deletes the tracked rows
+// in reverse foreign-key order (children first) inside a single transaction.
+package tracking
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"seedfast/cli/internal/sqlexec"
+	"seedfast/cli/internal/userdir"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// deleteBatchSize bounds the number of keys sent in a single DELETE statement.
+const deleteBatchSize = 1000
+
+// Entry is one batch of inserted rows for a table.
+type Entry struct {
+	// Table is the schema-qualified table name
+	Table string `json:"table"`
+	// Columns lists the primary key columns
+	Columns []string `json:"columns"`
+	// Keys holds one text-encoded primary key per inserted row, in Columns order
+	Keys [][]string `json:"keys"`
+}
+
+// TableRows aggregates the tracked keys of one table.
+type TableRows struct {
+	Table   string
+	Columns []string
+	Keys    [][]string
+}
+
+// Dir returns the directory holding tracking files, creating it if needed.
+func Dir() (string, error) {
+	return userdir.Sub("tracking")
+}
+
+// filePath returns the tracking file of a session.
+func filePath(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return "", fmt.Errorf("invalid session id %q", id)
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, id+".jsonl"), nil
+}
+
+// Recorder appends inserted keys for one session. It is safe for concurrent use
+// and creates the tracking file on the first write.
+type Recorder struct {
+	id   string
+	mu   sync.Mutex
+	f    *os.File
+	rows int
+	// err is the first write failure; rows inserted after it may be untracked
+	err error
+}
+
+// NewRecorder returns a recorder for the given session id.
+func NewRecorder(id string) *Recorder {
+	return &Recorder{id: id}
+}
+
+// Track implements sqlexec.RowTracker.
+func (r *Recorder) Track(table string, columns []string, keys [][]string) error {
+	if r == nil || len(keys) == 0 {
+		return nil
+	}
+	line, err := json.Marshal(Entry{Table: table, Columns: columns, Keys: keys})
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.write(line); err != nil {
+		if r.err == nil {
+			r.err = err
+		}
+		return err
+	}
+	r.rows += len(keys)
+	return nil
+}
+
+// write appends one line, opening the tracking file if needed.
+// Callers must hold r.mu.
+func (r *Recorder) write(line []byte) error {
+	if r.f == nil {
+		p, err := filePath(r.id)
+		if err != nil {
+			return err
+		}
+		f, err := os.OpenFile(p, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return err
+		}
+		r.f = f
+	}
+	_, err := r.f.Write(append(line, '\n'))
+	return err
+}
+
+// Err returns the first error met while recording keys, or nil. After an
+// error, undo can no longer remove every row inserted by the session.
+func (r *Recorder) Err() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Rows returns the number of rows tracked so far.
+func (r *Recorder) Rows() int {
+	if r == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rows
+}
+
+// Close closes the tracking file. It is a no-op if nothing was tracked.
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f == nil {
+		return nil
+	}
+	err := r.f.Close()
+	r.f = nil
+	return err
+}
+
+// Load reads the tracked rows of a session, grouped by table.
+func Load(id string) ([]TableRows, error) {
+	p, err := filePath(id)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no tracked rows for session %q", id)
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	byTable := make(map[string]*TableRows)
+	var order []string
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for sc.Scan() {
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			// A partially written last line is expected after a crash
+			continue
+		}
+		t, ok := byTable[e.Table]
+		if !ok {
+			t = &TableRows{Table: e.Table, Columns: e.Columns}
+			byTable[e.Table] = t
+			order = append(order, e.Table)
+		}
+		t.Keys = append(t.Keys, e.Keys...)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	out := make([]TableRows, 0, len(order))
+	for _, name := range order {
+		out = append(out, *byTable[name])
+	}
+	return out, nil
+}
+
+// Sessions returns the ids of all sessions with tracked rows, newest first.
+func Sessions() ([]string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".jsonl") {
+			ids = append(ids, strings.TrimSuffix(e.Name(), ".jsonl"))
+		}
+	}
+	// Session ids start with a UTC timestamp, so they sort chronologically
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	return ids, nil
+}
+
+// Remove deletes the tracking file of a session.
+func Remove(id string) error {
+	p, err := filePath(id)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(p)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Prune removes the tracking files of all but the keep most recent sessions.
+func Prune(keep int) error {
+	ids, err := Sessions()
+	if err != nil {
+		return err
+	}
+	for i := keep; i < len(ids); i++ {
+		if err := Remove(ids[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UndoOrder returns the tracked tables in deletion order: tables referencing
+// others come before the tables they reference.
+func UndoOrder(ctx context.Context, pool *pgxpool.Pool, rows []TableRows) ([]TableRows, error) {
+	names := make([]string, len(rows))
+	byName := make(map[string]TableRows, len(rows))
+	for i, r := range rows {
+		names[i] = r.Table
+		byName[sqlexec.QualifyTableName(r.Table)] = r
+	}
+	fks, err := sqlexec.NewSchemaInspector(pool).GetForeignKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	order := sqlexec.DependencyOrder(names, fks)
+	out := make([]TableRows, 0, len(order))
+	for i := len(order) - 1; i >= 0; i-- {
+		out = append(out, byName[order[i]])
+	}
+	return out, nil
+}
+
+// Undo deletes the tracked rows in the given order inside a single transaction
+// and returns the number of rows deleted per table. Rows that no longer exist
+// are skipped; rows still referenced by other data make the whole undo fail.
+func Undo(ctx context.Context, pool *pgxpool.Pool, order []TableRows) (map[string]int64, error) {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	deleted := make(map[string]int64, len(order))
+	for _, t := range order {
+		types, err := columnTypes(ctx, tx, t.Table, t.Columns)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t.Table, err)
+		}
+		stmt := deleteStatement(t.Table, t.Columns, types)
+		for start := 0; start < len(t.Keys); start += deleteBatchSize {
+			end := start + deleteBatchSize
+			if end > len(t.Keys) {
+				end = len(t.Keys)
+			}
+			args := make([]any, len(t.Columns))
+			for c := range t.Columns {
+				col := make([]string, 0, end-start)
+				for _, key := range t.Keys[start:end] {
+					if c < len(key) {
+						col = append(col, key[c])
+					}
+				}
+				args[c] = col
+			}
+			ct, err := tx.Exec(ctx, stmt, args...)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", t.Table, err)
+			}
+			deleted[t.Table] += ct.RowsAffected()
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
+
+// columnTypes returns the SQL types of the given columns, in order.
+func columnTypes(ctx context.Context, tx pgx.Tx, table string, columns []string) ([]string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT a.attname, format_type(a.atttypid, a.atttypmod)
+		FROM pg_attribute a
+		WHERE a.attrelid = $1::regclass AND a.attname = ANY($2) AND NOT a.attisdropped`,
+		sqlexec.QuoteTableName(table), columns)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byName := make(map[string]string, len(columns))
+	for rows.Next() {
+		var name, typ string
+		if err := rows.Scan(&name, &typ); err != nil {
+			return nil, err
+		}
+		byName[name] = typ
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	types := make([]string, len(columns))
+	for i, c := range columns {
+		typ, ok := byName[c]
+		if !ok {
+			return nil, fmt.Errorf("primary key column %q not found", c)
+		}
+		types[i] = typ
+	}
+	return types, nil
+}
+
+// deleteStatement builds a DELETE matching rows by primary key. Keys are passed
+// as one text array per column and cast back to the column types, so the
+// primary key index can be used.
+func deleteStatement(table string, columns, types []string) string {
+	cols := make([]string, len(columns))
+	casts := make([]string, len(columns))
+	params := make([]string, len(columns))
+	aliases := make([]string, len(columns))
+	for i, c := range columns {
+		cols[i] = pgx.Identifier{c}.Sanitize()
+		aliases[i] = fmt.Sprintf("k%d", i+1)
+		casts[i] = fmt.Sprintf("k.%s::%s", aliases[i], types[i])
+		params[i] = fmt.Sprintf("$%d::text[]", i+1)
+	}
+	return fmt.Sprintf("DELETE FROM %s WHERE (%s) IN (SELECT %s FROM unnest(%s) AS k(%s))",
+		sqlexec.QuoteTableName(table),
+		strings.Join(cols, ", "),
+		strings.Join(casts, ", "),
+		strings.Join(params, ", "),
+		strings.Join(aliases, ", "))
+}
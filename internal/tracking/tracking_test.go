@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+package tracking
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecorderRoundTrip(t *testing.T) {
+	t.Setenv("SEEDFAST_CONFIG_DIR", t.TempDir())
+
+	r := NewRecorder("20251023T101500Z-abc123")
+	if err := r.Track("public.users", []string{"id"}, [][]string{{"1"}, {"2"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Track("public.orders", []string{"user_id", "n"}, [][]string{{"1", "1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Track("public.users", []string{"id"}, [][]string{{"3"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.Rows(); got != 4 {
+		t.Fatalf("Rows() = %d, want 4", got)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a crash in the middle of a write
+	dir, _ := Dir()
+	f, err := os.OpenFile(filepath.Join(dir, "20251023T101500Z-abc123.jsonl"), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"table":"public.users","col`)
+	f.Close()
+
+	rows, err := Load("20251023T101500Z-abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0].Table != "public.users" || len(rows[0].Keys) != 3 || len(rows[1].Keys) != 1 {
+		t.Fatalf("unexpected rows: %+v", rows)
+	}
+
+	ids, err := Sessions()
+	if err != nil || len(ids) != 1 {
+		t.Fatalf("Sessions() = %v, %v", ids, err)
+	}
+	if err := Remove(ids[0]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(ids[0]); err == nil {
+		t.Fatal("expected error after Remove")
+	}
+}
+
+func TestRecorderErrAndPrune(t *testing.T) {
+	t.Setenv("SEEDFAST_CONFIG_DIR", t.TempDir())
+
+	for _, id := range []string{"20251023T101500Z-aaa", "20251024T101500Z-bbb", "20251025T101500Z-ccc"} {
+		r := NewRecorder(id)
+		if err := r.Track("public.users", []string{"id"}, [][]string{{"1"}}); err != nil {
+			t.Fatal(err)
+		}
+		if r.Err() != nil {
+			t.Fatalf("Err() = %v", r.Err())
+		}
+		r.Close()
+	}
+	if err := Prune(2); err != nil {
+		t.Fatal(err)
+	}
+	ids, err := Sessions()
+	if err != nil || len(ids) != 2 || ids[1] != "20251024T101500Z-bbb" {
+		t.Fatalf("Sessions() after Prune = %v, %v", ids, err)
+	}
+
+	bad := NewRecorder("../escape")
+	if err := bad.Track("public.users", []string{"id"}, [][]string{{"1"}}); err == nil || bad.Err() == nil {
+		t.Fatal("expected a tracking error for an invalid session id")
+	}
+}
+
+func TestDeleteStatement(t *testing.T) {
+	got := deleteStatement("public.order_items", []string{"order_id", "line"}, []string{"uuid", "integer"})
+	want := `DELETE FROM "public"."order_items" WHERE ("order_id", "line") IN (SELECT k.k1::uuid, k.k2::integer FROM unnest($1::text[], $2::text[]) AS k(k1, k2))`
+	if got != want {
+		t.Fatalf("deleteStatement:\n got %s\nwant %s", got, want)
+	}
+}
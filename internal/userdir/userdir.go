@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Seedfast
+// Licensed under the MIT License. See LICENSE file in the project root for details.
+
+// Package userdir resolves the per-user Seedfast directory used for local,
+// non-secret data such as snapshots and session records. Secrets are kept in
+// the OS keychain via internal/keychain; the only secrets stored here are the
+// encrypted files of its file backend.
+//
+// The directory is resolved in this order:
+//   - $SEEDFAST_CONFIG_DIR
+//   - $XDG_CONFIG_HOME/seedfast
+//   - ~/.config/seedfast
+package userdir
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Dir returns the Seedfast user directory without creating it.
+func Dir() (string, error) {
+	if d := os.Getenv("SEEDFAST_CONFIG_DIR"); d != "" {
+		return d, nil
+	}
+	if x := os.Getenv("XDG_CONFIG_HOME"); x != "" {
+		return filepath.Join(x, "seedfast"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return "", errors.New("cannot determine home directory for seedfast data")
+	}
+	return filepath.Join(home, ".config", "seedfast"), nil
+}
+
+// Sub returns a subdirectory of the Seedfast user directory, creating it
+// with owner-only permissions if needed.
+func Sub(name string) (string, error) {
+	base, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	p := filepath.Join(base, name)
+	if err := os.MkdirAll(p, 0o700); err != nil {
+		return "", err
+	}
+	return p, nil
+}